@@ -4,6 +4,7 @@ package gomappergen
 
 import (
 	"context"
+	"fmt"
 	"maps"
 	"os"
 	"slices"
@@ -17,15 +18,30 @@ import (
 type Config struct {
 	BuiltInConverters   BuiltInConverterConfig
 	LibraryConverters   LibraryConverterConfig
+	NumericConverter    NumericConverterConfig
+	TimeConverter       TimeConverterConfig
+	StrconvConverter    StrconvConverterConfig
 	ConverterFunctions  []ConvertFunctionConfig
 	ConverterPriorities []string
 	Packages            map[string][]PackageConfig
 }
 
 type Output struct {
-	PkgName      string
-	FileName     string
-	TestFileName string
+	PkgName        string
+	FileName       string
+	TestFileName   string
+	Dir            string
+	ImportPath     string
+	Split          OutputSplit
+	StructFileName string
+}
+
+// PkgPath returns the import path of the package which the generated code lives in.
+func (o Output) PkgPath(currentPkgPath string) string {
+	if o.Dir == "" {
+		return currentPkgPath
+	}
+	return o.ImportPath
 }
 
 type BuiltInConverterConfig struct {
@@ -35,12 +51,33 @@ type BuiltInConverterConfig struct {
 	UsePointerToType bool
 	UseNumeric       bool
 	UseFunctions     bool
+	UseTime          bool
+	UseStrconv       bool
+	UseText          bool
+	UseDiscovery     bool
 }
 
 type LibraryConverterConfig struct {
 	UseGRPC   bool
 	UsePGType bool
 	UseSQL    bool
+	UseUUID   bool
+	NullMode  NullMode
+	UUIDParse ParseMode
+}
+
+type NumericConverterConfig struct {
+	Narrowing NarrowingMode
+}
+
+type TimeConverterConfig struct {
+	Layout string
+	Unit   TimeUnit
+	Parse  ParseMode
+}
+
+type StrconvConverterConfig struct {
+	Parse ParseMode
 }
 
 func (c *BuiltInConverterConfig) EnableAll() {
@@ -53,16 +90,18 @@ func (c *BuiltInConverterConfig) EnableAll() {
 }
 
 type PackageConfig struct {
-	Mode                   Mode
-	Output                 Output
-	InterfaceName          string
-	ImplementationName     string
-	ConstructorName        string
-	DecoratorMode          DecoratorMode
-	DecoratorInterfaceName string
-	DecoratorNoOpName      string
-	Structs                []StructConfig
-	GenerateGoDoc          bool
+	Mode                    Mode
+	Output                  Output
+	InterfaceName           string
+	ImplementationName      string
+	ConstructorName         string
+	DecoratorMode           DecoratorMode
+	DecoratorInterfaceName  string
+	DecoratorNoOpName       string
+	Structs                 []StructConfig
+	GenerateGoDoc           bool
+	GenerateTest            bool
+	GenerateRoundTripReport bool
 }
 
 type FieldConfig struct {
@@ -76,6 +115,10 @@ func (c FieldConfig) Flip() FieldConfig {
 	}
 	mm := make(map[string]string)
 	for k, v := range c.ManualMap {
+		// a param cannot be a target field
+		if strings.HasPrefix(v, ParamReferencePrefix) {
+			continue
+		}
 		mm[v] = k
 	}
 	return FieldConfig{NameMatch: c.NameMatch, ManualMap: mm}
@@ -86,6 +129,11 @@ type ConvertFunctionConfig struct {
 	TypeName    string
 }
 
+// IsPattern reports whether TypeName is a wildcard pattern such as "*" or "To*".
+func (c ConvertFunctionConfig) IsPattern() bool {
+	return strings.ContainsAny(c.TypeName, "*?")
+}
+
 type StructConfig struct {
 	MapperName       string
 	TargetPkgPath    string
@@ -93,6 +141,13 @@ type StructConfig struct {
 	SourcePkgPath    string
 	SourceStructName string
 
+	// Sources are the source structs of a multi-source mapping in precedence order, it is
+	// empty for a mapping which uses SourcePkgPath and SourceStructName.
+	Sources []SourceConfig
+
+	// Params are the extra parameters of the mapping functions, ordered by name.
+	Params []ParamConfig
+
 	SourceToTargetFuncName   string
 	SourceFromTargetFuncName string
 	DecorateFuncName         string
@@ -107,6 +162,31 @@ type StructConfig struct {
 
 	GenerateSourceToTarget   bool
 	GenerateSourceFromTarget bool
+
+	ReturnError bool
+}
+
+// SourceConfig is a source struct of a multi-source mapping.
+type SourceConfig struct {
+	ParamName  string
+	PkgPath    string
+	StructName string
+}
+
+// ParamReferencePrefix is the prefix of a manually mapped source field which refers to a
+// param of the mapping functions, e.g. $tenantID.
+const ParamReferencePrefix = "$"
+
+// ParamConfig is an extra parameter of the mapping functions, Type is a basic type or a
+// type qualified by its package path, optionally prefixed by * or [].
+type ParamConfig struct {
+	Name string
+	Type string
+}
+
+// IsMultiSource reports whether the struct is mapped from more than one source struct.
+func (c StructConfig) IsMultiSource() bool {
+	return len(c.Sources) > 0
 }
 
 type Mode int
@@ -116,6 +196,13 @@ const (
 	ModeFunctions
 )
 
+type OutputSplit int
+
+const (
+	OutputSplitNone OutputSplit = iota
+	OutputSplitPerStruct
+)
+
 type DecoratorMode int
 
 const (
@@ -133,6 +220,68 @@ const (
 	PointerBoth
 )
 
+// NullMode defines how a nullable library type such as pgtype.Text or sql.NullString
+// is converted from/to its non-pointer value type.
+type NullMode int
+
+const (
+	// NullModePointer converts via the pointer type, e.g. pgtype.Text -> *string -> string.
+	NullModePointer NullMode = iota
+
+	// NullModeZeroOnNull converts NULL to the zero value, a value is always valid.
+	NullModeZeroOnNull
+
+	// NullModeValidIfNonZero converts NULL to the zero value and the zero value to NULL.
+	NullModeValidIfNonZero
+
+	// NullModeAlwaysValid reads the value without checking validity, a value is always valid.
+	NullModeAlwaysValid
+)
+
+// ParseMode defines how a conversion which can fail, such as parsing a string, handles
+// the failure.
+type ParseMode int
+
+const (
+	// ParseModeError returns the error, it is only available when the mapper returns an error.
+	ParseModeError ParseMode = iota
+
+	// ParseModeMust panics when the conversion fails.
+	ParseModeMust
+
+	// ParseModeZero leaves the target as the zero value when the conversion fails.
+	ParseModeZero
+)
+
+// NarrowingMode defines how the numeric converter handles a conversion which may lose
+// the value, such as int64 -> int32 or float64 -> int.
+type NarrowingMode int
+
+const (
+	// NarrowingModeAllow casts the value without checking.
+	NarrowingModeAllow NarrowingMode = iota
+
+	// NarrowingModeWarn casts the value without checking, but logs a warning and emits a comment.
+	NarrowingModeWarn
+
+	// NarrowingModeChecked checks the value against the bounds of the target type, the error
+	// is returned if the mapper returns an error, otherwise the value is clamped.
+	NarrowingModeChecked
+
+	// NarrowingModeDeny makes the conversion unconvertible.
+	NarrowingModeDeny
+)
+
+// TimeUnit defines the unit of a Unix timestamp converted from/to time.Time.
+type TimeUnit int
+
+const (
+	TimeUnitSecond TimeUnit = iota
+	TimeUnitMillisecond
+	TimeUnitMicrosecond
+	TimeUnitNanosecond
+)
+
 type NameMatch int
 
 const (
@@ -173,9 +322,11 @@ var Placeholder = cfPlaceHolder{
 
 var Default = defaultCfValue{
 	Output: Output{
-		PkgName:      Placeholder.CurrentPackageName,
-		FileName:     "gen_mapper.go",
-		TestFileName: "gen_mapper_test.go",
+		PkgName:        Placeholder.CurrentPackageName,
+		FileName:       "gen_mapper.go",
+		TestFileName:   "gen_mapper_test.go",
+		Split:          OutputSplitNone,
+		StructFileName: "gen_mapper_{TargetStructName}.go",
 	},
 	Mode:                     ModeTypes,
 	InterfaceName:            "iMapper",
@@ -211,9 +362,17 @@ func MakeConfig(cfg pkl.Config, provider FieldInterceptorProvider) (*Config, err
 		return nil, err
 	}
 
+	libraryConverters, err := m.mapLibraryConverterConfig(cfg.Converter.BuiltIn)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Config{
 		BuiltInConverters:   m.mapBuiltInConverterConfig(cfg.Converter.BuiltIn),
-		LibraryConverters:   m.mapLibraryConverterConfig(cfg.Converter.BuiltIn),
+		LibraryConverters:   libraryConverters,
+		NumericConverter:    NumericConverterConfig{Narrowing: m.mapNarrowingMode(cfg.Converter.Numeric.Narrowing)},
+		TimeConverter:       m.mapTimeConverterConfig(cfg.Converter.Time),
+		StrconvConverter:    StrconvConverterConfig{Parse: m.mapParseMode(cfg.Converter.Strconv.Parse)},
 		ConverterFunctions:  m.mapConverterFunctions(cfg.Converter.Functions),
 		ConverterPriorities: cfg.Converter.Priorities,
 		Packages:            pkgConfigs,
@@ -232,14 +391,34 @@ func (m *configMapper) mapBuiltInConverterConfig(in mapper.BuiltInConverter) Bui
 		UsePointerToType: in.EnablePointerToType,
 		UseNumeric:       in.EnableNumeric,
 		UseFunctions:     in.EnableFunctions,
+		UseTime:          in.EnableTime,
+		UseStrconv:       in.EnableStrconv,
+		UseText:          in.EnableText,
+		UseDiscovery:     in.EnableDiscovery,
 	}
 }
 
-func (m *configMapper) mapLibraryConverterConfig(in mapper.BuiltInConverter) LibraryConverterConfig {
+func (m *configMapper) mapLibraryConverterConfig(in mapper.BuiltInConverter) (LibraryConverterConfig, error) {
+	nullMode, err := m.mapNullMode(in.Library.NullMode)
+	if err != nil {
+		return LibraryConverterConfig{}, err
+	}
+
 	return LibraryConverterConfig{
 		UseGRPC:   in.Library.EnableGrpc,
 		UsePGType: in.Library.EnablePgtype,
 		UseSQL:    in.Library.EnableSql,
+		UseUUID:   in.Library.EnableUuid,
+		NullMode:  nullMode,
+		UUIDParse: m.mapParseMode(in.Library.UuidParse),
+	}, nil
+}
+
+func (m *configMapper) mapTimeConverterConfig(in mapper.TimeConverter) TimeConverterConfig {
+	return TimeConverterConfig{
+		Layout: in.Layout,
+		Unit:   m.mapTimeUnit(in.Unit),
+		Parse:  m.mapParseMode(in.Parse),
 	}
 }
 
@@ -301,15 +480,17 @@ func (m *configMapper) mapMapper(cf pkl.Package, all pkl.All) *PackageConfig {
 	}
 
 	pkgCf := PackageConfig{
-		Output:                 m.mergeOutput(&all.Output, cf.GetOutput()),
-		Mode:                   m.mapMode(cf.GetMode()),
-		InterfaceName:          cf.GetInterfaceName(),
-		ImplementationName:     cf.GetImplementationName(),
-		ConstructorName:        cf.GetConstructorName(),
-		DecoratorMode:          m.mapDecoratorMode(cf.GetDecoratorMode()),
-		DecoratorInterfaceName: cf.GetDecoratorInterfaceName(),
-		DecoratorNoOpName:      cf.GetDecoratorNoopName(),
-		GenerateGoDoc:          cf.GetGenerateGoDoc(),
+		Output:                  m.mergeOutput(&all.Output, cf.GetOutput()),
+		Mode:                    m.mapMode(cf.GetMode()),
+		InterfaceName:           cf.GetInterfaceName(),
+		ImplementationName:      cf.GetImplementationName(),
+		ConstructorName:         cf.GetConstructorName(),
+		DecoratorMode:           m.mapDecoratorMode(cf.GetDecoratorMode()),
+		DecoratorInterfaceName:  cf.GetDecoratorInterfaceName(),
+		DecoratorNoOpName:       cf.GetDecoratorNoopName(),
+		GenerateGoDoc:           cf.GetGenerateGoDoc(),
+		GenerateTest:            cf.GetGenerateTest(),
+		GenerateRoundTripReport: cf.GetGenerateRoundTripReport(),
 	}
 
 	var structs []StructConfig
@@ -323,12 +504,15 @@ func (m *configMapper) mapMapper(cf pkl.Package, all pkl.All) *PackageConfig {
 		if v.TargetStructName != nil {
 			targetStructName = *v.TargetStructName
 		}
+		sourcePkgPath := mergeConfigValue(v.SourcePkg, cf.GetSourcePkg())
 		structCf := StructConfig{
 			MapperName:               mapperName,
 			TargetPkgPath:            mergeConfigValue(v.TargetPkg, cf.GetTargetPkg()),
 			TargetStructName:         targetStructName,
-			SourcePkgPath:            mergeConfigValue(v.SourcePkg, cf.GetSourcePkg()),
+			SourcePkgPath:            sourcePkgPath,
 			SourceStructName:         mergeConfigValue(v.SourceStructName, targetStructName),
+			Sources:                  m.mapSources(v.Sources, v.SourcePrecedence, sourcePkgPath),
+			Params:                   m.mapParams(v.Params),
 			SourceToTargetFuncName:   mergeConfigValue(v.SourceToTargetFunctionName, cf.GetSourceToTargetFunctionName()),
 			SourceFromTargetFuncName: mergeConfigValue(v.SourceFromTargetFunctionName, cf.GetSourceFromTargetFunctionName()),
 			DecorateFuncName:         mergeConfigValue(v.DecorateFunctionName, cf.GetDecorateFunctionName()),
@@ -339,6 +523,11 @@ func (m *configMapper) mapMapper(cf pkl.Package, all pkl.All) *PackageConfig {
 			UseGetter:                mergeConfigValue(v.UseGetterIfAvailable, cf.GetUseGetterIfAvailable()),
 			GenerateSourceToTarget:   mergeConfigValue(v.GenerateSourceToTarget, cf.GetGenerateSourceToTarget()),
 			GenerateSourceFromTarget: mergeConfigValue(v.GenerateSourceFromTarget, cf.GetGenerateSourceFromTarget()),
+			ReturnError:              mergeConfigValue(v.ReturnError, cf.GetReturnError()),
+		}
+
+		if structCf.IsMultiSource() {
+			structCf.GenerateSourceFromTarget = false
 		}
 
 		structs = append(structs, structCf)
@@ -348,6 +537,53 @@ func (m *configMapper) mapMapper(cf pkl.Package, all pkl.All) *PackageConfig {
 	return &pkgCf
 }
 
+// mapSources returns the sources ordered by precedence, the sources which are not in
+// precedence follow the listed ones in order of their names.
+func (m *configMapper) mapSources(sources *map[string]string, precedence *[]string, pkgPath string) []SourceConfig {
+	if sources == nil || len(*sources) == 0 {
+		return nil
+	}
+
+	names := slices.Sorted(maps.Keys(*sources))
+	if precedence != nil {
+		var ordered []string
+		for _, name := range *precedence {
+			if slices.Contains(names, name) && !slices.Contains(ordered, name) {
+				ordered = append(ordered, name)
+			}
+		}
+
+		for _, name := range names {
+			if !slices.Contains(ordered, name) {
+				ordered = append(ordered, name)
+			}
+		}
+		names = ordered
+	}
+
+	var result []SourceConfig
+	for _, name := range names {
+		source := parseConverterFunctionConfigFromString((*sources)[name])
+		if source.PackagePath == "" {
+			source.PackagePath = pkgPath
+		}
+		result = append(result, SourceConfig{ParamName: name, PkgPath: source.PackagePath, StructName: source.TypeName})
+	}
+	return result
+}
+
+func (m *configMapper) mapParams(params *map[string]string) []ParamConfig {
+	if params == nil || len(*params) == 0 {
+		return nil
+	}
+
+	var result []ParamConfig
+	for _, name := range slices.Sorted(maps.Keys(*params)) {
+		result = append(result, ParamConfig{Name: name, Type: (*params)[name]})
+	}
+	return result
+}
+
 func (m *configMapper) mapMode(val string) Mode {
 	switch val {
 	case "types":
@@ -359,6 +595,17 @@ func (m *configMapper) mapMode(val string) Mode {
 	}
 }
 
+func (m *configMapper) mapOutputSplit(val string) OutputSplit {
+	switch val {
+	case "none":
+		return OutputSplitNone
+	case "per-struct":
+		return OutputSplitPerStruct
+	default:
+		return OutputSplitNone
+	}
+}
+
 func (m *configMapper) mapDecoratorMode(val string) DecoratorMode {
 	switch val {
 	case "adaptive":
@@ -372,6 +619,76 @@ func (m *configMapper) mapDecoratorMode(val string) DecoratorMode {
 	}
 }
 
+func (m *configMapper) mapNullMode(val *string) (NullMode, error) {
+	if val == nil {
+		return NullModePointer, nil
+	}
+
+	mode, ok := parseNullMode(*val)
+	if !ok {
+		return NullModePointer, fmt.Errorf("invalid null_mode %q", *val)
+	}
+	return mode, nil
+}
+
+func parseNullMode(val string) (NullMode, bool) {
+	switch val {
+	case "pointer":
+		return NullModePointer, true
+	case "zero-on-null":
+		return NullModeZeroOnNull, true
+	case "valid-if-non-zero":
+		return NullModeValidIfNonZero, true
+	case "always-valid":
+		return NullModeAlwaysValid, true
+	default:
+		return NullModePointer, false
+	}
+}
+
+func (m *configMapper) mapParseMode(val string) ParseMode {
+	switch val {
+	case "error":
+		return ParseModeError
+	case "must":
+		return ParseModeMust
+	case "zero":
+		return ParseModeZero
+	default:
+		return ParseModeError
+	}
+}
+
+func (m *configMapper) mapNarrowingMode(val string) NarrowingMode {
+	switch val {
+	case "allow":
+		return NarrowingModeAllow
+	case "warn":
+		return NarrowingModeWarn
+	case "checked":
+		return NarrowingModeChecked
+	case "deny":
+		return NarrowingModeDeny
+	default:
+		return NarrowingModeAllow
+	}
+}
+
+func (m *configMapper) mapTimeUnit(val string) TimeUnit {
+	switch val {
+	case "seconds":
+		return TimeUnitSecond
+	case "milliseconds":
+		return TimeUnitMillisecond
+	case "microseconds":
+		return TimeUnitMicrosecond
+	case "nanoseconds":
+		return TimeUnitNanosecond
+	default:
+		return TimeUnitSecond
+	}
+}
+
 func (m *configMapper) mapPointer(val string) Pointer {
 	switch val {
 	case "none":
@@ -416,6 +733,22 @@ func (m *configMapper) mergeOutput(outputs ...*pkl.Output) Output {
 		if o.TestFileName != nil {
 			output.TestFileName = *o.TestFileName
 		}
+
+		if o.Dir != nil {
+			output.Dir = *o.Dir
+		}
+
+		if o.ImportPath != nil {
+			output.ImportPath = *o.ImportPath
+		}
+
+		if o.Split != nil {
+			output.Split = m.mapOutputSplit(*o.Split)
+		}
+
+		if o.StructFileName != nil {
+			output.StructFileName = *o.StructFileName
+		}
 	}
 	return *output
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/toniphan21/go-mapper-gen/internal/setup"
 	"github.com/toniphan21/go-mapper-gen/internal/setup/file"
+	pklgen "github.com/toniphan21/go-mapper-gen/pkg/pkl"
 )
 
 func TestParseConfig_FileNotFound(t *testing.T) {
@@ -305,6 +306,12 @@ func Test_parseConverterFunctionConfigFromString(t *testing.T) {
 			input:    "github.com/toniphan21/go-mapper-gen.Type",
 			expected: ConvertFunctionConfig{PackagePath: "github.com/toniphan21/go-mapper-gen", TypeName: "Type"},
 		},
+
+		{
+			name:     "pattern: github.com/toniphan21/go-mapper-gen/convert.To*",
+			input:    "github.com/toniphan21/go-mapper-gen/convert.To*",
+			expected: ConvertFunctionConfig{PackagePath: "github.com/toniphan21/go-mapper-gen/convert", TypeName: "To*"},
+		},
 	}
 
 	for _, tc := range cases {
@@ -315,3 +322,14 @@ func Test_parseConverterFunctionConfigFromString(t *testing.T) {
 		})
 	}
 }
+
+func TestMakeConfig_InvalidNullMode(t *testing.T) {
+	mode := "never-null"
+	cfg := pklgen.Config{}
+	cfg.Converter.BuiltIn.Library.NullMode = &mode
+
+	cf, err := MakeConfig(cfg, DefaultFieldInterceptorProvider())
+
+	assert.Nil(t, cf)
+	assert.ErrorContains(t, err, `invalid null_mode "never-null"`)
+}
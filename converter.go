@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"go/types"
 	"log/slog"
+	"slices"
 
 	"github.com/dave/jennifer/jen"
 )
@@ -90,7 +91,8 @@ func (s Symbol) toGetterSymbol(getterName string) Symbol {
 // Converter defines the contract for converting a source value or field
 // into a target value or field during code generation.
 type Converter interface {
-	// Init is called once before code generation starts.
+	// Init is called once before code generation starts, every generator initializes its own
+	// copy of a converter which is a pointer of a struct.
 	// It allows the converter to initialize internal state, validate assumptions,
 	// or prepare data required during code generation. Init must not emit code.
 	// If no initialization is required, the implementation may be a no-op.
@@ -113,6 +115,33 @@ type Converter interface {
 	ConvertField(ctx ConverterContext, target, source Symbol) jen.Code
 }
 
+// NullModeConverter is implemented by converters of nullable library types such as
+// pgtype.Text or sql.NullString. The "null-mode" field interceptor uses it to override
+// the NullMode configured globally for a specific field.
+type NullModeConverter interface {
+	Converter
+
+	// CanConvertWithNullMode is the same as CanConvert but uses the given mode instead
+	// of the configured one.
+	CanConvertWithNullMode(ctx LookupContext, mode NullMode, targetType, sourceType types.Type) bool
+
+	// ConvertFieldWithNullMode is the same as ConvertField but uses the given mode
+	// instead of the configured one.
+	ConvertFieldWithNullMode(ctx ConverterContext, mode NullMode, target, source Symbol) jen.Code
+}
+
+// RoundTripLossReporter is implemented by converters which know why a field they convert may
+// not be equal after mapping to the target and back, e.g. a time formatted by a layout. A
+// field converted by a converter of another package which does not implement it is reported
+// as unknown, and is not asserted by the generated round-trip test.
+type RoundTripLossReporter interface {
+	Converter
+
+	// RoundTripLoss returns the reason why a field converted from sourceType to targetType
+	// and back may not be equal, or an empty string if it is always equal.
+	RoundTripLoss(targetType, sourceType types.Type) string
+}
+
 // ConverterContext provides shared capabilities and state for converters
 // during code generation. It embeds context.Context to support cancellation
 // and timeouts defined by the generator.
@@ -143,6 +172,21 @@ type ConverterContext interface {
 	EmitTraceComments() bool
 }
 
+// ErrorReturner is implemented by the ConverterContext which the generator passes to converters,
+// its LookupContext implements CanReturnError only. It is not a part of these interfaces so their
+// existing implementations keep working, use GeneratorUtil.CanReturnError and
+// GeneratorUtil.ReturnError instead of type-asserting it.
+type ErrorReturner interface {
+	// CanReturnError reports whether the mapper function being generated returns an
+	// error. A converter which can fail should only be convertible when it is true,
+	// unless it handles the failure in another way.
+	CanReturnError() bool
+
+	// ReturnError returns the statement which returns err from the mapper function
+	// being generated. It must only be used when CanReturnError returns true.
+	ReturnError(err jen.Code) jen.Code
+}
+
 type converterContext struct {
 	context.Context
 	jenFile           *jen.File
@@ -150,6 +194,7 @@ type converterContext struct {
 	currentVarCount   int
 	lookupContext     *lookupContext
 	emitTraceComments bool
+	zeroResults       []jen.Code
 }
 
 func (c *converterContext) LookUp(current Converter, targetType, sourceType types.Type) (Converter, error) {
@@ -201,6 +246,21 @@ func (c *converterContext) EmitTraceComments() bool {
 	return c.emitTraceComments
 }
 
+func (c *converterContext) CanReturnError() bool {
+	return c.lookupContext.returnError
+}
+
+func (c *converterContext) ReturnError(err jen.Code) jen.Code {
+	/** generated code:
+	return [[zeroResults]], err
+	*/
+	return jen.Return(append(slices.Clone(c.zeroResults), err)...)
+}
+
+func (c *converterContext) Param(name string) (Symbol, bool) {
+	return c.lookupContext.Param(name)
+}
+
 func (c *converterContext) Logger() *slog.Logger {
 	return c.lookupContext.logger
 }
@@ -210,12 +270,30 @@ func (c *converterContext) resetVarCount() {
 }
 
 func (c *converterContext) resetLookupContext(target Descriptor, source Descriptor) {
+	// nil converters means all converters of the scope
 	c.lookupContext.converters = nil
 	c.lookupContext.target = target
 	c.lookupContext.source = source
 	c.lookupContext.interceptor = nil
 }
 
+// setReturnError sets whether the mapper function being generated returns an error,
+// zeroResults are the values returned together with the error.
+func (c *converterContext) setReturnError(returnError bool, zeroResults ...jen.Code) {
+	c.lookupContext.returnError = returnError
+	c.zeroResults = zeroResults
+}
+
+// setJenFile sets the file which the mapper function being generated is emitted into.
+func (c *converterContext) setJenFile(file *jen.File) {
+	c.jenFile = file
+}
+
+// setParams sets the extra parameters of the mapper function being generated.
+func (c *converterContext) setParams(params []Symbol) {
+	c.lookupContext.params = params
+}
+
 func (c *converterContext) setFieldInterceptor(interceptor FieldInterceptor) {
 	c.lookupContext.interceptor = interceptor
 }
@@ -234,3 +312,4 @@ func (c *converterContext) SourceDescriptor() *Descriptor {
 
 var _ ConverterContext = (*converterContext)(nil)
 var _ LookupContext = (*converterContext)(nil)
+var _ ErrorReturner = (*converterContext)(nil)
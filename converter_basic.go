@@ -1,6 +1,7 @@
 package gomappergen
 
 import (
+	"fmt"
 	"go/types"
 	"log/slog"
 
@@ -9,9 +10,11 @@ import (
 
 type numericConverter struct {
 	numericTypes []types.Type
+	narrowing    NarrowingMode
 }
 
-func (c *numericConverter) Init(_ Parser, _ Config, _ *slog.Logger) {
+func (c *numericConverter) Init(_ Parser, config Config, _ *slog.Logger) {
+	c.narrowing = config.NumericConverter.Narrowing
 	c.numericTypes = []types.Type{
 		types.Typ[types.Int],
 		types.Typ[types.Int8],
@@ -38,33 +41,32 @@ func (c *numericConverter) Info() ConverterInfo {
 
 func (c *numericConverter) CanConvert(ctx LookupContext, targetType, sourceType types.Type) bool {
 	if c.isNumeric(sourceType) && c.isNumeric(targetType) {
-		return true
+		return c.canCast(sourceType, targetType)
 	}
 
 	if c.isNumeric(sourceType) {
-		_, _, ok := c.lookUpConvertibleFromNumeric(ctx, targetType)
+		_, _, ok := c.lookUpConvertibleFromNumeric(ctx, targetType, sourceType)
 		return ok
 	}
 
 	if c.isNumeric(targetType) {
-		_, _, ok := c.lookUpConverterToNumeric(ctx, sourceType)
+		_, _, ok := c.lookUpConverterToNumeric(ctx, sourceType, targetType)
 		return ok
 	}
 
-	_, _, ok1 := c.lookUpConverterToNumeric(ctx, sourceType)
-	_, _, ok2 := c.lookUpConvertibleFromNumeric(ctx, targetType)
-	return ok1 && ok2
+	_, beforeNumericType, ok1 := c.lookUpConverterToNumeric(ctx, sourceType, nil)
+	_, afterNumericType, ok2 := c.lookUpConvertibleFromNumeric(ctx, targetType, nil)
+	return ok1 && ok2 && c.canCast(beforeNumericType, afterNumericType)
 }
 
 func (c *numericConverter) ConvertField(ctx ConverterContext, target, source Symbol) jen.Code {
 	return ctx.Run(c, func() jen.Code {
 		switch {
 		case c.isNumeric(target.Type) && c.isNumeric(source.Type):
-			code := jen.Add(GeneratorUtil.TypeToJenCode(target.Type)).Params(source.Expr())
-			return target.Expr().Op("=").Add(code)
+			return c.cast(ctx, target.Expr(), "=", source.Expr(), source.Type, target.Type)
 
 		case c.isNumeric(target.Type):
-			oc, numericType, ok := c.lookUpConverterToNumeric(ctx, source.Type)
+			oc, numericType, ok := c.lookUpConverterToNumeric(ctx, source.Type, target.Type)
 			if !ok {
 				return nil
 			}
@@ -81,19 +83,17 @@ func (c *numericConverter) ConvertField(ctx ConverterContext, target, source Sym
 			code.Add(convertedCode).Line()
 
 			// then convert numericType -> targetType by casting
-			rhs := jen.Add(GeneratorUtil.TypeToJenCode(target.Type)).Params(jen.Id(varName))
-			return code.Add(target.Expr().Op("=").Add(rhs))
+			return code.Add(c.cast(ctx, target.Expr(), "=", jen.Id(varName), numericType, target.Type))
 
 		case c.isNumeric(source.Type):
-			oc, numericType, ok := c.lookUpConvertibleFromNumeric(ctx, target.Type)
+			oc, numericType, ok := c.lookUpConvertibleFromNumeric(ctx, target.Type, source.Type)
 			if !ok {
 				return nil
 			}
 
 			// first convert source.Type to numericType by casting
 			varName := ctx.NextVarName()
-			rhs := jen.Add(GeneratorUtil.TypeToJenCode(numericType)).Params(source.Expr())
-			code := jen.Id(varName).Op(":=").Add(rhs).Line()
+			code := jen.Add(c.cast(ctx, jen.Id(varName), ":=", source.Expr(), source.Type, numericType)).Line()
 
 			// then convert numericType to target.Type using oc - other converter
 			sourceSymbol := Symbol{VarName: varName, Type: numericType}
@@ -104,9 +104,9 @@ func (c *numericConverter) ConvertField(ctx ConverterContext, target, source Sym
 			return code.Add(convertedCode).Line()
 
 		default:
-			bc, beforeNumericType, ok1 := c.lookUpConverterToNumeric(ctx, source.Type)
-			ac, afterNumericType, ok2 := c.lookUpConvertibleFromNumeric(ctx, target.Type)
-			if !ok1 || !ok2 {
+			bc, beforeNumericType, ok1 := c.lookUpConverterToNumeric(ctx, source.Type, nil)
+			ac, afterNumericType, ok2 := c.lookUpConvertibleFromNumeric(ctx, target.Type, nil)
+			if !ok1 || !ok2 || !c.canCast(beforeNumericType, afterNumericType) {
 				return nil
 			}
 
@@ -123,9 +123,7 @@ func (c *numericConverter) ConvertField(ctx ConverterContext, target, source Sym
 
 			// then convert beforeNumericType -> afterNumericType by casting
 			afterVarName := ctx.NextVarName()
-
-			rhs := jen.Add(GeneratorUtil.TypeToJenCode(afterNumericType)).Params(jen.Id(beforeVarName))
-			code = code.Add(jen.Id(afterVarName).Op(":=").Add(rhs).Line())
+			code = code.Add(c.cast(ctx, jen.Id(afterVarName), ":=", jen.Id(beforeVarName), beforeNumericType, afterNumericType)).Line()
 
 			// then convert afterNumericType -> target.Type using ac - after converter
 			sourceSymbol := Symbol{VarName: afterVarName, Type: afterNumericType}
@@ -148,8 +146,14 @@ func (c *numericConverter) isNumeric(t types.Type) bool {
 	return basic.Info()&numeric != 0
 }
 
-func (c *numericConverter) lookUpConverterToNumeric(ctx LookupContext, t types.Type) (Converter, types.Type, bool) {
+// lookUpConverterToNumeric finds a converter t -> numeric type, the numeric type has to be
+// castable to castTo if it is given.
+func (c *numericConverter) lookUpConverterToNumeric(ctx LookupContext, t types.Type, castTo types.Type) (Converter, types.Type, bool) {
 	for _, n := range c.numericTypes {
+		if castTo != nil && !c.canCast(n, castTo) {
+			continue
+		}
+
 		v, _ := ctx.LookUp(c, n, t)
 		if v != nil {
 			return v, n, true
@@ -158,8 +162,14 @@ func (c *numericConverter) lookUpConverterToNumeric(ctx LookupContext, t types.T
 	return nil, nil, false
 }
 
-func (c *numericConverter) lookUpConvertibleFromNumeric(ctx LookupContext, t types.Type) (Converter, types.Type, bool) {
+// lookUpConvertibleFromNumeric finds a converter numeric type -> t, castFrom has to be
+// castable to the numeric type if it is given.
+func (c *numericConverter) lookUpConvertibleFromNumeric(ctx LookupContext, t types.Type, castFrom types.Type) (Converter, types.Type, bool) {
 	for _, n := range c.numericTypes {
+		if castFrom != nil && !c.canCast(castFrom, n) {
+			continue
+		}
+
 		v, _ := ctx.LookUp(c, t, n)
 		if v != nil {
 			return v, n, true
@@ -168,4 +178,225 @@ func (c *numericConverter) lookUpConvertibleFromNumeric(ctx LookupContext, t typ
 	return nil, nil, false
 }
 
+func (c *numericConverter) canCast(from, to types.Type) bool {
+	if c.narrowing != NarrowingModeDeny {
+		return true
+	}
+	_, _, narrowing := c.narrowingBounds(from, to)
+	return !narrowing
+}
+
+// cast emits the code which casts value of type from to type to then assigns it to lhs
+// via op, the narrowing conversion is handled depends on the NarrowingMode.
+func (c *numericConverter) cast(ctx ConverterContext, lhs jen.Code, op string, value jen.Code, from, to types.Type) jen.Code {
+	/** generated code:
+	{lhs} = T({value})
+
+	// or if the conversion is narrowing and narrowing mode is warn
+	// narrowing conversion: int64 -> int32
+	{lhs} = T({value})
+
+	// or if the conversion is narrowing, narrowing mode is checked and the mapper returns error
+	if {value} < math.MinInt32 || {value} > math.MaxInt32 {
+		return ..., fmt.Errorf("cannot convert %v to int32: value out of range", {value})
+	}
+	{lhs} = T({value})
+
+	// or if the value is a float, the upper bound is exclusive because math.MaxInt64 is rounded up as a float
+	if {value} != {value} || {value} < math.MinInt64 || {value} >= math.MaxInt64+1 {
+		return ..., fmt.Errorf("cannot convert %v to int64: value out of range", {value})
+	}
+	{lhs} = T({value})
+
+	// or if the conversion is narrowing, narrowing mode is checked and the mapper does not return error
+	{lhs} = T(min(max({value}, math.MinInt32), math.MaxInt32))
+	{lhs} = T(min(max({value}, math.MinInt64), math.MaxInt64-1023)) // the largest float64 below 2^63
+	*/
+	typeCode := GeneratorUtil.TypeToJenCode(to)
+	lower, upper, narrowing := c.narrowingBounds(from, to)
+	if !narrowing {
+		return jen.Add(lhs).Op(op).Add(typeCode).Params(value)
+	}
+	floatToInteger := c.isFloat(from) && !c.isFloat(to)
+
+	switch c.narrowing {
+	case NarrowingModeWarn:
+		fromName, toName := GeneratorUtil.SimpleName(from), GeneratorUtil.SimpleName(to)
+		ctx.Logger().Warn(
+			"\tnarrowing numeric conversion",
+			slog.String("target_field", ctx.TargetDescriptor().FieldName()),
+			slog.String("source_field", ctx.SourceDescriptor().FieldName()),
+			slog.String("from", fromName),
+			slog.String("to", toName),
+		)
+
+		code := jen.Comment(fmt.Sprintf("narrowing conversion: %s -> %s", fromName, toName)).Line()
+		return code.Add(lhs).Op(op).Add(typeCode).Params(value)
+
+	case NarrowingModeChecked:
+		if GeneratorUtil.CanReturnError(ctx) {
+			var conditions []jen.Code
+			if floatToInteger {
+				conditions = append(conditions, jen.Add(value).Op("!=").Add(value))
+			}
+			if lower != nil {
+				conditions = append(conditions, jen.Add(value).Op("<").Add(lower))
+			}
+			if upper != nil && floatToInteger {
+				conditions = append(conditions, jen.Add(value).Op(">=").Add(upper).Op("+").Lit(1))
+			} else if upper != nil {
+				conditions = append(conditions, jen.Add(value).Op(">").Add(upper))
+			}
+
+			message := fmt.Sprintf("cannot convert %%v to %s: value out of range", GeneratorUtil.SimpleName(to))
+			err := jen.Qual("fmt", "Errorf").Call(jen.Lit(message), value)
+			code := jen.If(jen.Add(conditions[0]).Do(func(s *jen.Statement) {
+				for _, v := range conditions[1:] {
+					s.Op("||").Add(v)
+				}
+			})).Block(GeneratorUtil.ReturnError(ctx, err)).Line()
+			return code.Add(lhs).Op(op).Add(typeCode).Params(value)
+		}
+
+		clamped := value
+		if lower != nil {
+			clamped = jen.Max(clamped, lower)
+		}
+		if upper != nil && floatToInteger {
+			clamped = jen.Min(clamped, c.floatUpperBound(from, to, upper))
+		} else if upper != nil {
+			clamped = jen.Min(clamped, upper)
+		}
+		return jen.Add(lhs).Op(op).Add(typeCode).Params(clamped)
+
+	default:
+		return jen.Add(lhs).Op(op).Add(typeCode).Params(value)
+	}
+}
+
+// narrowingBounds returns the bounds of type to which a value of type from has to be
+// checked against, narrowing is false if all values of type from fit in type to.
+// Converting an integer to a float is not narrowing even if it may lose precision.
+func (c *numericConverter) narrowingBounds(from, to types.Type) (lower, upper jen.Code, narrowing bool) {
+	fb, ok1 := from.Underlying().(*types.Basic)
+	tb, ok2 := to.Underlying().(*types.Basic)
+	if !ok1 || !ok2 {
+		return nil, nil, false
+	}
+
+	fs, ts := c.sizeOf(fb), c.sizeOf(tb)
+	fromSigned := fb.Info()&types.IsUnsigned == 0
+	switch {
+	case tb.Info()&types.IsFloat != 0:
+		if fb.Info()&types.IsFloat != 0 && fs > ts {
+			return jen.Op("-").Qual("math", "MaxFloat32"), jen.Qual("math", "MaxFloat32"), true
+		}
+		return nil, nil, false
+
+	case tb.Info()&types.IsUnsigned != 0:
+		if fb.Info()&types.IsFloat != 0 || (fromSigned && fs > ts) {
+			return jen.Lit(0), c.maxOf(tb), true
+		}
+		if fromSigned {
+			return jen.Lit(0), nil, true
+		}
+		if fs > ts {
+			return nil, c.maxOf(tb), true
+		}
+		return nil, nil, false
+
+	default:
+		if fb.Info()&types.IsFloat != 0 || (fromSigned && fs > ts) {
+			return c.minOf(tb), c.maxOf(tb), true
+		}
+		if !fromSigned && fs >= ts {
+			return nil, c.maxOf(tb), true
+		}
+		return nil, nil, false
+	}
+}
+
+// floatUpperBound returns the largest value of float type from which does not overflow the
+// integer type to, upper is the max value of type to. The max value of a 64-bit integer, or a
+// 32-bit integer for float32, is not a float, it is rounded up to a power of two which
+// overflows the integer, so the largest float below it is used instead.
+func (c *numericConverter) floatUpperBound(from, to types.Type, upper jen.Code) jen.Code {
+	fb, tb := from.Underlying().(*types.Basic), to.Underlying().(*types.Basic)
+
+	mantissa := 53
+	if fb.Kind() == types.Float32 {
+		mantissa = 24
+	}
+
+	bits := c.sizeOf(tb) * 8
+	if tb.Info()&types.IsUnsigned == 0 {
+		bits--
+	}
+
+	if bits <= mantissa {
+		return upper
+	}
+	return jen.Add(upper).Op("-").Lit(1<<(bits-mantissa) - 1)
+}
+
+func (c *numericConverter) isFloat(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsFloat != 0
+}
+
+// sizeOf returns the size of a numeric type in bytes, int, uint and uintptr are 8 bytes
+// as the generated code targets 64-bit platforms.
+func (c *numericConverter) sizeOf(basic *types.Basic) int {
+	switch basic.Kind() {
+	case types.Int8, types.Uint8:
+		return 1
+	case types.Int16, types.Uint16:
+		return 2
+	case types.Int32, types.Uint32, types.Float32:
+		return 4
+	default:
+		return 8
+	}
+}
+
+func (c *numericConverter) minOf(basic *types.Basic) jen.Code {
+	switch basic.Kind() {
+	case types.Int8:
+		return jen.Qual("math", "MinInt8")
+	case types.Int16:
+		return jen.Qual("math", "MinInt16")
+	case types.Int32:
+		return jen.Qual("math", "MinInt32")
+	case types.Int64:
+		return jen.Qual("math", "MinInt64")
+	default:
+		return jen.Qual("math", "MinInt")
+	}
+}
+
+func (c *numericConverter) maxOf(basic *types.Basic) jen.Code {
+	switch basic.Kind() {
+	case types.Int8:
+		return jen.Qual("math", "MaxInt8")
+	case types.Int16:
+		return jen.Qual("math", "MaxInt16")
+	case types.Int32:
+		return jen.Qual("math", "MaxInt32")
+	case types.Int64:
+		return jen.Qual("math", "MaxInt64")
+	case types.Int:
+		return jen.Qual("math", "MaxInt")
+	case types.Uint8:
+		return jen.Qual("math", "MaxUint8")
+	case types.Uint16:
+		return jen.Qual("math", "MaxUint16")
+	case types.Uint32:
+		return jen.Qual("math", "MaxUint32")
+	case types.Uint64, types.Uintptr:
+		return jen.Qual("math", "MaxUint64")
+	default:
+		return jen.Qual("math", "MaxUint")
+	}
+}
+
 var _ Converter = (*numericConverter)(nil)
@@ -0,0 +1,229 @@
+package gomappergen
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_numericConverter_narrowing(t *testing.T) {
+	config := func(mode NarrowingMode) *Config {
+		return &Config{NumericConverter: NumericConverterConfig{Narrowing: mode}}
+	}
+
+	cases := []ConverterTestCase{
+		{Name: "deny int64 to int32", Config: config(NarrowingModeDeny), SourceType: "int64", TargetType: "int32"},
+		{Name: "deny int to uint", Config: config(NarrowingModeDeny), SourceType: "int", TargetType: "uint"},
+		{Name: "deny float64 to int64", Config: config(NarrowingModeDeny), SourceType: "float64", TargetType: "int64"},
+		{Name: "deny uint64 to int64", Config: config(NarrowingModeDeny), SourceType: "uint64", TargetType: "int64"},
+
+		{
+			Name:               "deny allows widening int32 to int64",
+			Config:             config(NarrowingModeDeny),
+			SourceType:         "int32",
+			TargetType:         "int64",
+			ExpectedCanConvert: true,
+			ExpectedCode:       []string{"out.targetField = int64(in.sourceField)"},
+		},
+
+		{
+			Name:               "deny allows int64 to float64",
+			Config:             config(NarrowingModeDeny),
+			SourceType:         "int64",
+			TargetType:         "float64",
+			ExpectedCanConvert: true,
+			ExpectedCode:       []string{"out.targetField = float64(in.sourceField)"},
+		},
+
+		{
+			Name:               "allow int64 to int32",
+			Config:             config(NarrowingModeAllow),
+			SourceType:         "int64",
+			TargetType:         "int32",
+			ExpectedCanConvert: true,
+			ExpectedCode:       []string{"out.targetField = int32(in.sourceField)"},
+		},
+
+		{
+			Name:               "warn int64 to int32",
+			Config:             config(NarrowingModeWarn),
+			SourceType:         "int64",
+			TargetType:         "int32",
+			ExpectedCanConvert: true,
+			ExpectedCode: []string{
+				"// narrowing conversion: int64 -> int32",
+				"out.targetField = int32(in.sourceField)",
+			},
+		},
+
+		{
+			Name:               "checked int64 to int32 with error",
+			Config:             config(NarrowingModeChecked),
+			SourceType:         "int64",
+			TargetType:         "int32",
+			ReturnError:        true,
+			ExpectedCanConvert: true,
+			ExpectedImports:    []string{"import (", `	"fmt"`, `	"math"`, ")"},
+			ExpectedCode: []string{
+				"if in.sourceField < math.MinInt32 || in.sourceField > math.MaxInt32 {",
+				`	return fmt.Errorf("cannot convert %v to int32: value out of range", in.sourceField)`,
+				"}",
+				"out.targetField = int32(in.sourceField)",
+			},
+		},
+
+		{
+			Name:               "checked int to uint with error",
+			Config:             config(NarrowingModeChecked),
+			SourceType:         "int",
+			TargetType:         "uint",
+			ReturnError:        true,
+			ExpectedCanConvert: true,
+			ExpectedImports:    []string{`import "fmt"`},
+			ExpectedCode: []string{
+				"if in.sourceField < 0 {",
+				`	return fmt.Errorf("cannot convert %v to uint: value out of range", in.sourceField)`,
+				"}",
+				"out.targetField = uint(in.sourceField)",
+			},
+		},
+
+		{
+			Name:               "checked uint64 to int64 with clamping",
+			Config:             config(NarrowingModeChecked),
+			SourceType:         "uint64",
+			TargetType:         "int64",
+			ExpectedCanConvert: true,
+			ExpectedImports:    []string{`import "math"`},
+			ExpectedCode:       []string{"out.targetField = int64(min(in.sourceField, math.MaxInt64))"},
+		},
+
+		{
+			Name:               "checked float64 to uint8 with clamping",
+			Config:             config(NarrowingModeChecked),
+			SourceType:         "float64",
+			TargetType:         "uint8",
+			ExpectedCanConvert: true,
+			ExpectedImports:    []string{`import "math"`},
+			ExpectedCode:       []string{"out.targetField = uint8(min(max(in.sourceField, 0), math.MaxUint8))"},
+		},
+
+		{
+			Name:               "checked float64 to int64 with error",
+			Config:             config(NarrowingModeChecked),
+			SourceType:         "float64",
+			TargetType:         "int64",
+			ReturnError:        true,
+			ExpectedCanConvert: true,
+			ExpectedImports:    []string{"import (", `	"fmt"`, `	"math"`, ")"},
+			ExpectedCode: []string{
+				"if in.sourceField != in.sourceField || in.sourceField < math.MinInt64 || in.sourceField >= math.MaxInt64+1 {",
+				`	return fmt.Errorf("cannot convert %v to int64: value out of range", in.sourceField)`,
+				"}",
+				"out.targetField = int64(in.sourceField)",
+			},
+		},
+
+		{
+			Name:               "checked float32 to uint32 with error",
+			Config:             config(NarrowingModeChecked),
+			SourceType:         "float32",
+			TargetType:         "uint32",
+			ReturnError:        true,
+			ExpectedCanConvert: true,
+			ExpectedImports:    []string{"import (", `	"fmt"`, `	"math"`, ")"},
+			ExpectedCode: []string{
+				"if in.sourceField != in.sourceField || in.sourceField < 0 || in.sourceField >= math.MaxUint32+1 {",
+				`	return fmt.Errorf("cannot convert %v to uint32: value out of range", in.sourceField)`,
+				"}",
+				"out.targetField = uint32(in.sourceField)",
+			},
+		},
+
+		{
+			Name:               "checked float64 to int64 with clamping",
+			Config:             config(NarrowingModeChecked),
+			SourceType:         "float64",
+			TargetType:         "int64",
+			ExpectedCanConvert: true,
+			ExpectedImports:    []string{`import "math"`},
+			ExpectedCode:       []string{"out.targetField = int64(min(max(in.sourceField, math.MinInt64), math.MaxInt64-1023))"},
+		},
+
+		{
+			Name:               "checked float64 to uint64 with clamping",
+			Config:             config(NarrowingModeChecked),
+			SourceType:         "float64",
+			TargetType:         "uint64",
+			ExpectedCanConvert: true,
+			ExpectedImports:    []string{`import "math"`},
+			ExpectedCode:       []string{"out.targetField = uint64(min(max(in.sourceField, 0), math.MaxUint64-2047))"},
+		},
+
+		{
+			Name:               "checked float32 to int32 with clamping",
+			Config:             config(NarrowingModeChecked),
+			SourceType:         "float32",
+			TargetType:         "int32",
+			ExpectedCanConvert: true,
+			ExpectedImports:    []string{`import "math"`},
+			ExpectedCode:       []string{"out.targetField = int32(min(max(in.sourceField, math.MinInt32), math.MaxInt32-127))"},
+		},
+
+		{
+			Name:               "checked float32 to uint32 with clamping",
+			Config:             config(NarrowingModeChecked),
+			SourceType:         "float32",
+			TargetType:         "uint32",
+			ExpectedCanConvert: true,
+			ExpectedImports:    []string{`import "math"`},
+			ExpectedCode:       []string{"out.targetField = uint32(min(max(in.sourceField, 0), math.MaxUint32-255))"},
+		},
+		// ---
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			converter := &numericConverter{}
+			Test.RunConverterTestCase(t, tc, converter)
+		})
+	}
+}
+
+// Test_numericConverter_floatBounds checks that the bounds used for a float are exact: the
+// upper bound of the check is the first float which overflows, the upper bound of the clamp
+// is the float right below it and does not overflow.
+func Test_numericConverter_floatBounds(t *testing.T) {
+	t.Run("float64 to int64", func(t *testing.T) {
+		assert.Equal(t, float64(1<<63), float64(math.MaxInt64+1))
+		assert.Equal(t, int64(math.MaxInt64-1023), int64(float64(math.MaxInt64-1023)))
+		assert.Equal(t, float64(math.MaxInt64+1), math.Nextafter(math.MaxInt64-1023, math.Inf(1)))
+		assert.Equal(t, float64(math.MinInt64), float64(int64(math.MinInt64)))
+	})
+
+	t.Run("float64 to uint64", func(t *testing.T) {
+		assert.Equal(t, float64(1<<64), float64(math.MaxUint64+1))
+		assert.Equal(t, uint64(math.MaxUint64-2047), uint64(float64(math.MaxUint64-2047)))
+		assert.Equal(t, float64(math.MaxUint64+1), math.Nextafter(math.MaxUint64-2047, math.Inf(1)))
+	})
+
+	t.Run("float32 to int32", func(t *testing.T) {
+		assert.Equal(t, float32(1<<31), float32(math.MaxInt32+1))
+		assert.Equal(t, int32(math.MaxInt32-127), int32(float32(math.MaxInt32-127)))
+		assert.Equal(t, float32(math.MaxInt32+1), math.Nextafter32(math.MaxInt32-127, float32(math.Inf(1))))
+		assert.Equal(t, float32(math.MinInt32), float32(int32(math.MinInt32)))
+	})
+
+	t.Run("float32 to uint32", func(t *testing.T) {
+		assert.Equal(t, float32(1<<32), float32(math.MaxUint32+1))
+		assert.Equal(t, uint32(math.MaxUint32-255), uint32(float32(math.MaxUint32-255)))
+		assert.Equal(t, float32(math.MaxUint32+1), math.Nextafter32(math.MaxUint32-255, float32(math.Inf(1))))
+	})
+
+	t.Run("NaN", func(t *testing.T) {
+		nan := math.NaN()
+		assert.False(t, nan < math.MinInt64 || nan >= math.MaxInt64+1)
+		assert.True(t, nan != nan)
+	})
+}
@@ -0,0 +1,210 @@
+package gomappergen
+
+import (
+	"go/types"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// discoveredFunc is a method of the source type or a constructor function in the target
+// type's package which converts the source type to the target type.
+type discoveredFunc struct {
+	pkgPath      string
+	name         string
+	isMethod     bool
+	returnsError bool
+}
+
+// discoveryConverter converts a type by discovering conversion functions instead of
+// listing them in converter { functions }. Candidates are ranked deterministically:
+//
+//  1. an exported zero-arg method on the source type which returns the target type,
+//     named To<Target>, As<Target> or <Target>, or the only such method if there is
+//     no method with these names
+//  2. a function named <Type>From<Source> in the target type's package
+//  3. a function named New<Type> in the target type's package
+//
+// Functions returning (T, error) are only used when the mapper returns an error.
+type discoveryConverter struct {
+	errorType types.Type
+}
+
+func (c *discoveryConverter) Init(_ Parser, _ Config, _ *slog.Logger) {
+	c.errorType = types.Universe.Lookup("error").Type()
+}
+
+func (c *discoveryConverter) Info() ConverterInfo {
+	return ConverterInfo{
+		Name:                 "built-in discoveryConverter",
+		ShortForm:            "T.ToV() | V.New(T) -> V",
+		ShortFormDescription: "invoke discovered methods of source type or constructors of target type",
+	}
+}
+
+func (c *discoveryConverter) CanConvert(ctx LookupContext, targetType, sourceType types.Type) bool {
+	return c.discover(ctx, targetType, sourceType) != nil
+}
+
+func (c *discoveryConverter) ConvertField(ctx ConverterContext, target, source Symbol) jen.Code {
+	return ctx.Run(c, func() jen.Code {
+		fn := c.discover(ctx, target.Type, source.Type)
+		if fn == nil {
+			return nil
+		}
+
+		if fn.isMethod {
+			ctx.Logger().Debug(
+				"\tconvert via discovered method",
+				slog.String("target_field", ctx.TargetDescriptor().FieldName()),
+				slog.String("source_field", ctx.SourceDescriptor().FieldName()),
+				slog.String("method", GeneratorUtil.SimpleName(source.Type)+"."+fn.name),
+			)
+
+			/** generated code:
+			{target} = {source}.ToString()
+			*/
+			return target.Expr().Op("=").Add(source.Expr()).Dot(fn.name).Call()
+		}
+
+		ctx.Logger().Debug(
+			"\tconvert via discovered function",
+			slog.String("target_field", ctx.TargetDescriptor().FieldName()),
+			slog.String("source_field", ctx.SourceDescriptor().FieldName()),
+			slog.String("function", fn.pkgPath+"."+fn.name),
+		)
+
+		call := jen.Qual(fn.pkgPath, fn.name).Call(source.Expr())
+		if !fn.returnsError {
+			/** generated code:
+			{target} = pkg.NewEmail({source})
+			*/
+			return target.Expr().Op("=").Add(call)
+		}
+
+		/** generated code:
+		v0, err := pkg.NewEmail({source})
+		if err != nil {
+			return ..., err
+		}
+		{target} = v0
+		*/
+		return GeneratorUtil.parseCode(ctx, ParseModeError, call, func(v jen.Code) jen.Code {
+			return target.Expr().Op("=").Add(v)
+		})
+	})
+}
+
+func (c *discoveryConverter) discover(ctx LookupContext, targetType, sourceType types.Type) *discoveredFunc {
+	if fn := c.discoverMethod(targetType, sourceType); fn != nil {
+		return fn
+	}
+	return c.discoverConstructor(ctx, targetType, sourceType)
+}
+
+// discoverMethod finds a method in the value method set of sourceType, pointer receiver
+// methods are not used because the source may not be addressable, e.g. a getter's result.
+func (c *discoveryConverter) discoverMethod(targetType, sourceType types.Type) *discoveredFunc {
+	if _, ok := types.Unalias(sourceType).(*types.Named); !ok || TypeUtil.IsInterface(sourceType) {
+		return nil
+	}
+
+	var candidates []string
+	methodSet := types.NewMethodSet(sourceType)
+	for i := 0; i < methodSet.Len(); i++ {
+		fn, ok := methodSet.At(i).Obj().(*types.Func)
+		if !ok || !fn.Exported() {
+			continue
+		}
+
+		sig := fn.Type().(*types.Signature)
+		if sig.Params().Len() == 0 && sig.Results().Len() == 1 && TypeUtil.IsIdentical(sig.Results().At(0).Type(), targetType) {
+			candidates = append(candidates, fn.Name())
+		}
+	}
+
+	if targetName := c.typeName(targetType); targetName != "" {
+		for _, name := range []string{"To" + targetName, "As" + targetName, targetName} {
+			if slices.Contains(candidates, name) {
+				return &discoveredFunc{name: name, isMethod: true}
+			}
+		}
+	}
+
+	// there is no way to rank methods with unrelated names, e.g. time.Time's Year() and
+	// Day() both return int, so a method is only chosen if it is the only candidate
+	if len(candidates) == 1 {
+		return &discoveredFunc{name: candidates[0], isMethod: true}
+	}
+	return nil
+}
+
+// discoverConstructor finds a function named <Type>From<Source> or New<Type> in the
+// package of targetType, or of its element type if targetType is a pointer.
+func (c *discoveryConverter) discoverConstructor(ctx LookupContext, targetType, sourceType types.Type) *discoveredFunc {
+	elemType := targetType
+	if ptr, ok := targetType.(*types.Pointer); ok {
+		elemType = ptr.Elem()
+	}
+
+	named, ok := types.Unalias(elemType).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return nil
+	}
+
+	typeName := named.Obj().Name()
+	names := []string{"New" + typeName}
+	if sourceName := c.typeName(sourceType); sourceName != "" {
+		names = []string{typeName + "From" + sourceName, "New" + typeName}
+	}
+
+	pkg := named.Obj().Pkg()
+	for _, name := range names {
+		fn, ok := pkg.Scope().Lookup(name).(*types.Func)
+		if !ok || !fn.Exported() {
+			continue
+		}
+
+		sig := fn.Type().(*types.Signature)
+		if sig.TypeParams().Len() != 0 || sig.Variadic() || sig.Params().Len() != 1 {
+			continue
+		}
+
+		if !TypeUtil.IsIdentical(sig.Params().At(0).Type(), sourceType) {
+			continue
+		}
+
+		results := sig.Results()
+		if results.Len() == 0 || !TypeUtil.IsIdentical(results.At(0).Type(), targetType) {
+			continue
+		}
+
+		switch {
+		case results.Len() == 1:
+			return &discoveredFunc{pkgPath: pkg.Path(), name: name}
+
+		case results.Len() == 2 && TypeUtil.IsIdentical(results.At(1).Type(), c.errorType) && GeneratorUtil.CanReturnError(ctx):
+			return &discoveredFunc{pkgPath: pkg.Path(), name: name, returnsError: true}
+		}
+	}
+	return nil
+}
+
+// typeName returns the name of a named or basic type in the form used in function names,
+// e.g. String for string, or an empty string for other types.
+func (c *discoveryConverter) typeName(t types.Type) string {
+	var name string
+	switch tt := types.Unalias(t).(type) {
+	case *types.Named:
+		name = tt.Obj().Name()
+	case *types.Basic:
+		name = tt.Name()
+	default:
+		return ""
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+var _ Converter = (*discoveryConverter)(nil)
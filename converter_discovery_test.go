@@ -0,0 +1,114 @@
+package gomappergen
+
+import "testing"
+
+func Test_discoveryConverter(t *testing.T) {
+	additionalCode := []string{
+		`type Email string`,
+		``,
+		`func (e Email) ToString() string { return string(e) }`,
+		``,
+		`func (e Email) Domain() string { return "" }`,
+		``,
+		`type Money struct{ cents int64 }`,
+		``,
+		`func (m Money) Cents() int64 { return m.cents }`,
+		``,
+		`func NewMoney(cents int64) Money { return Money{cents: cents} }`,
+		``,
+		`type Date struct{}`,
+		``,
+		`func (d Date) Year() int { return 0 }`,
+		``,
+		`func (d Date) Month() int { return 0 }`,
+		``,
+		`func NewDate(v string) (Date, error) { return Date{}, nil }`,
+		``,
+		`type Phone string`,
+		``,
+		`func NewPhone(v string) Phone { return Phone(v) }`,
+		``,
+		`func PhoneFromString(v string) Phone { return Phone(v) }`,
+		``,
+		`type Name string`,
+		``,
+		`func (n *Name) ToString() string { return string(*n) }`,
+		``,
+		`func NewName(v string, other string) Name { return Name(v) }`,
+		``,
+	}
+	config := &Config{}
+
+	cases := []ConverterTestCase{
+		{Name: "cannot convert Date to int if methods are ambiguous", AdditionalCode: additionalCode, Config: config, SourceType: "Date", TargetType: "int"},
+		{Name: "cannot convert string to Date without returning error", AdditionalCode: additionalCode, Config: config, SourceType: "string", TargetType: "Date"},
+		{Name: "cannot convert Name to string via pointer receiver method", AdditionalCode: additionalCode, Config: config, SourceType: "Name", TargetType: "string"},
+		{Name: "cannot convert string to Name via constructor with 2 params", AdditionalCode: additionalCode, Config: config, SourceType: "string", TargetType: "Name"},
+		{Name: "cannot convert int to Money", AdditionalCode: additionalCode, Config: config, SourceType: "int", TargetType: "Money"},
+
+		{
+			Name:               "Email to string via ToString() ranked before Domain()",
+			AdditionalCode:     additionalCode,
+			Config:             config,
+			SourceType:         "Email",
+			TargetType:         "string",
+			ExpectedCanConvert: true,
+			ExpectedCode:       []string{"out.targetField = in.sourceField.ToString()"},
+		},
+
+		{
+			Name:               "Money to int64 via the only candidate Cents()",
+			AdditionalCode:     additionalCode,
+			Config:             config,
+			SourceType:         "Money",
+			TargetType:         "int64",
+			ExpectedCanConvert: true,
+			ExpectedCode:       []string{"out.targetField = in.sourceField.Cents()"},
+		},
+
+		{
+			Name:               "int64 to Money via NewMoney()",
+			AdditionalCode:     additionalCode,
+			Config:             config,
+			SourceType:         "int64",
+			TargetType:         "Money",
+			ExpectedCanConvert: true,
+			ExpectedCode:       []string{"out.targetField = NewMoney(in.sourceField)"},
+		},
+
+		{
+			Name:               "string to Phone via PhoneFromString() ranked before NewPhone()",
+			AdditionalCode:     additionalCode,
+			Config:             config,
+			SourceType:         "string",
+			TargetType:         "Phone",
+			ExpectedCanConvert: true,
+			ExpectedCode:       []string{"out.targetField = PhoneFromString(in.sourceField)"},
+		},
+
+		{
+			Name:               "string to Date via NewDate() returning error",
+			AdditionalCode:     additionalCode,
+			Config:             config,
+			SourceType:         "string",
+			TargetType:         "Date",
+			ReturnError:        true,
+			ExpectedCanConvert: true,
+			ExpectedCode: []string{
+				"v0, err := NewDate(in.sourceField)",
+				"if err != nil {",
+				"	return err",
+				"}",
+				"out.targetField = v0",
+			},
+		},
+		// ---
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			converter := &discoveryConverter{}
+			Test.RunConverterTestCase(t, tc, converter)
+		})
+	}
+}
@@ -1,10 +1,13 @@
 package gomappergen
 
 import (
+	"fmt"
+	"go/token"
 	"go/types"
 	"log/slog"
 
 	"github.com/dave/jennifer/jen"
+	"github.com/toniphan21/go-mapper-gen/internal/util"
 )
 
 type funcConverter struct {
@@ -29,26 +32,25 @@ type functionsConverter struct {
 	availableFunctions []funcConverter
 }
 
-func (c *functionsConverter) Init(parser Parser, config Config, _ *slog.Logger) {
-	if len(config.ConverterFunctions) == 0 {
-		// no-op
-		return
-	}
+// ConverterFunctionMarker is the doc comment line which registers a function in the source
+// code as a converter function, without listing it in converter { functions }.
+const ConverterFunctionMarker = "//mapper:converter"
 
+func (c *functionsConverter) Init(parser Parser, config Config, logger *slog.Logger) {
 	c.availableFunctions = make([]funcConverter, 0)
 	for _, v := range config.ConverterFunctions {
-		fn, ok := parser.FindFunction(v.PackagePath, v.TypeName)
-		if ok {
-			if len(fn.Params) != 1 || len(fn.Results) != 1 {
-				continue
+		if v.IsPattern() {
+			if ep, ok := parser.(ExtendedParser); ok {
+				c.addPatternFunctions(ep, v)
+			} else {
+				logger.Warn(util.ColorYellow(fmt.Sprintf("\tthe parser does not support converter function patterns, %s is skipped", v.TypeName)))
 			}
+			continue
+		}
 
-			c.availableFunctions = append(c.availableFunctions, funcConverter{
-				sourceType: fn.Params[0],
-				targetType: fn.Results[0],
-				pkgPath:    fn.PackagePath,
-				funcName:   fn.Name,
-			})
+		fn, ok := parser.FindFunction(v.PackagePath, v.TypeName)
+		if ok {
+			c.addFunction(fn, nil)
 			continue
 		}
 
@@ -56,23 +58,68 @@ func (c *functionsConverter) Init(parser Parser, config Config, _ *slog.Logger)
 		if len(varFns) > 0 {
 			variableName := v.TypeName
 			for _, vfn := range varFns {
-				if len(vfn.Params) != 1 || len(vfn.Results) != 1 {
-					continue
-				}
-
-				c.availableFunctions = append(c.availableFunctions, funcConverter{
-					sourceType:   vfn.Params[0],
-					targetType:   vfn.Results[0],
-					variableName: &variableName,
-					pkgPath:      vfn.PackagePath,
-					funcName:     vfn.Name,
-				})
-				continue
+				c.addFunction(vfn, &variableName)
+			}
+		}
+	}
+
+	ep, ok := parser.(ExtendedParser)
+	if !ok {
+		return
+	}
+
+	for _, fn := range ep.FindMarkedFunctions(ConverterFunctionMarker) {
+		if !c.addFunction(fn, nil) {
+			logger.Warn(util.ColorYellow(fmt.Sprintf(
+				"\tfunction %s.%s has %s but it does not have exactly one parameter and one result",
+				fn.PackagePath, fn.Name, ConverterFunctionMarker,
+			)))
+		}
+	}
+}
+
+// addPatternFunctions registers the exported functions and the exported methods of the
+// exported variables whose names match the wildcard pattern in config.TypeName.
+func (c *functionsConverter) addPatternFunctions(parser ExtendedParser, config ConvertFunctionConfig) {
+	for _, fn := range parser.FindFunctions(config.PackagePath, config.TypeName) {
+		c.addFunction(fn, nil)
+	}
+
+	for _, name := range parser.FindVariables(config.PackagePath, config.TypeName) {
+		variableName := name
+		for _, vfn := range parser.FindVariableMethods(config.PackagePath, name) {
+			if token.IsExported(vfn.Name) {
+				c.addFunction(vfn, &variableName)
 			}
 		}
 	}
 }
 
+// addFunction registers fn if it has exactly one parameter and one result, a function
+// which is already registered is skipped. It reports whether fn is usable.
+func (c *functionsConverter) addFunction(fn FuncInfo, variableName *string) bool {
+	if len(fn.Params) != 1 || len(fn.Results) != 1 {
+		return false
+	}
+
+	for _, v := range c.availableFunctions {
+		sameVariable := (v.variableName == nil && variableName == nil) ||
+			(v.variableName != nil && variableName != nil && *v.variableName == *variableName)
+		if sameVariable && v.pkgPath == fn.PackagePath && v.funcName == fn.Name {
+			return true
+		}
+	}
+
+	c.availableFunctions = append(c.availableFunctions, funcConverter{
+		sourceType:   fn.Params[0],
+		targetType:   fn.Results[0],
+		variableName: variableName,
+		pkgPath:      fn.PackagePath,
+		funcName:     fn.Name,
+	})
+	return true
+}
+
 func (c *functionsConverter) Info() ConverterInfo {
 	return ConverterInfo{
 		Name:                 "built-in functionsConverter",
@@ -342,3 +342,91 @@ func Test_functionsConverter_Use_Functions_And_MethodsInVariable(t *testing.T) {
 		})
 	}
 }
+
+func Test_functionsConverter_UsePatternAndMarker(t *testing.T) {
+	additionalCode := []string{
+		`type CustomType struct {`,
+		`	ID string`,
+		`}`,
+		``,
+		`type Email string`,
+		``,
+		`type convertHelper struct{}`,
+		``,
+		`func (h *convertHelper) ToEmail(s string) Email {`,
+		`	return Email(s)`,
+		`}`,
+		``,
+		`func ToCustomType(s string) CustomType {`,
+		`	return CustomType{ID: s}`,
+		`}`,
+		``,
+		`func FromCustomType(t CustomType) string {`,
+		`	return t.ID`,
+		`}`,
+		``,
+		`func ToCustomTypeIDs(ids ...string) CustomType {`,
+		`	return CustomType{}`,
+		`}`,
+		``,
+		`//mapper:converter`,
+		`func emailToString(e Email) string {`,
+		`	return string(e)`,
+		`}`,
+		``,
+		`var ToConverters = &convertHelper{}`,
+		``,
+	}
+
+	config := &Config{
+		ConverterFunctions: []ConvertFunctionConfig{
+			{
+				PackagePath: "github.com/toniphan21/go-mapper-gen/example",
+				TypeName:    "To*",
+			},
+		},
+	}
+
+	cases := []ConverterTestCase{
+		{Name: "FromCustomType does not match the pattern", AdditionalCode: additionalCode, Config: config, TargetType: "string", SourceType: "CustomType"},
+		{Name: "variadic ToCustomTypeIDs is skipped", AdditionalCode: additionalCode, Config: config, TargetType: "CustomType", SourceType: "[]string"},
+
+		{
+			Name:               "convert string to CustomType use ToCustomType matched by pattern",
+			AdditionalCode:     additionalCode,
+			Config:             config,
+			TargetType:         "CustomType",
+			SourceType:         "string",
+			ExpectedCanConvert: true,
+			ExpectedCode:       []string{"out.targetField = ToCustomType(in.sourceField)"},
+		},
+
+		{
+			Name:               "convert string to Email use method of ToConverters matched by pattern",
+			AdditionalCode:     additionalCode,
+			Config:             config,
+			TargetType:         "Email",
+			SourceType:         "string",
+			ExpectedCanConvert: true,
+			ExpectedCode:       []string{"out.targetField = ToConverters.ToEmail(in.sourceField)"},
+		},
+
+		{
+			Name:               "convert Email to string use emailToString marked by //mapper:converter",
+			AdditionalCode:     additionalCode,
+			Config:             config,
+			TargetType:         "string",
+			SourceType:         "Email",
+			ExpectedCanConvert: true,
+			ExpectedCode:       []string{"out.targetField = emailToString(in.sourceField)"},
+		},
+		// ---
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			converter := &functionsConverter{}
+			Test.RunConverterTestCase(t, tc, converter)
+		})
+	}
+}
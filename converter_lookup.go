@@ -4,17 +4,18 @@ import (
 	"fmt"
 	"go/types"
 	"log/slog"
+	"sync/atomic"
 
 	"github.com/dave/jennifer/jen"
 )
 
 var LookUpTotalHits uint64
 
-var enableLookUpCache = false
-
-func EnableLookUpCache() {
-	enableLookUpCache = true
-}
+// EnableLookUpCache does nothing.
+//
+// Deprecated: the lookup cache is enabled by default, use WithLookUpCacheSize to change its size
+// or to disable it.
+func EnableLookUpCache() {}
 
 type Descriptor struct {
 	structInfo      *StructInfo
@@ -56,14 +57,35 @@ func (d *Descriptor) FieldIndex() int {
 	return d.structFieldInfo.Index
 }
 
-type lookUpCache struct {
-	converter Converter
-	target    types.Type
-	source    types.Type
+// converterScope is the converters used by a generator and their lookup cache. It is owned by
+// the generator instead of being global, so packages can be generated concurrently.
+type converterScope struct {
+	registry *Registry
+	cache    *lookUpCache
+}
+
+func newConverterScope(registry *Registry, cacheSize int) *converterScope {
+	return &converterScope{registry: registry, cache: newLookUpCache(cacheSize)}
+}
+
+// registered returns the converters of the scope, a nil scope uses the default registry.
+func (s *converterScope) registered() []*registeredConverter {
+	if s == nil {
+		return defaultRegistry.registered()
+	}
+	return s.registry.registered()
+}
+
+// lookUpCache returns the cache of the scope, a nil scope has no cache.
+func (s *converterScope) lookUpCache() *lookUpCache {
+	if s == nil {
+		return nil
+	}
+	return s.cache
 }
 
 type LookupContext interface {
-	// LookUp searches the global converter registry for a converter that
+	// LookUp searches the converters of the generator for a converter that
 	// can convert a value of sourceType to targetType, excluding the provided
 	// currentConverter (if non-nil).
 	//
@@ -91,6 +113,10 @@ type LookupContext interface {
 
 	SourceDescriptor() *Descriptor
 
+	// Param returns the extra parameter of the mapper function being generated with
+	// the given name, configured via params in the struct config.
+	Param(name string) (Symbol, bool)
+
 	// Logger returns a slog handler that can be used for logging during
 	// code generation.
 	Logger() *slog.Logger
@@ -98,39 +124,53 @@ type LookupContext interface {
 
 type lookupContext struct {
 	logger      *slog.Logger
+	scope       *converterScope
 	converters  []*registeredConverter
 	target      Descriptor
 	source      Descriptor
 	interceptor FieldInterceptor
+	returnError bool
+	params      []Symbol
 }
 
-func newLookupContext(target Descriptor, source Descriptor, logger *slog.Logger) *lookupContext {
+func newLookupContext(target Descriptor, source Descriptor, returnError bool, logger *slog.Logger) *lookupContext {
 	return &lookupContext{
-		target: target,
-		source: source,
-		logger: logger,
+		target:      target,
+		source:      source,
+		returnError: returnError,
+		logger:      logger,
 	}
 }
 
-func emptyLookupContext(logger *slog.Logger) *lookupContext {
-	return &lookupContext{logger: logger}
+// withScope sets the converters which are looked up, the default registry is used if it is not set.
+func (l *lookupContext) withScope(scope *converterScope) *lookupContext {
+	l.scope = scope
+	return l
 }
 
-var lkCache []lookUpCache
+// withParams sets the extra parameters of the mapper function being generated.
+func (l *lookupContext) withParams(params []Symbol) *lookupContext {
+	l.params = params
+	return l
+}
+
+func emptyLookupContext(scope *converterScope, logger *slog.Logger) *lookupContext {
+	return &lookupContext{scope: scope, logger: logger}
+}
 
 func (l *lookupContext) LookUp(current Converter, targetType, sourceType types.Type) (Converter, error) {
 	if current == nil {
 		return nil, fmt.Errorf("invalid: current converter is nil")
 	}
 
-	var reachable []*registeredConverter
-	var available []*registeredConverter
-	if l.converters == nil {
-		available = globalConverters
-	} else {
-		available = l.converters
+	available := l.converters
+	if available == nil {
+		available = l.scope.registered()
 	}
 
+	// reachable is never nil, a nil converters falls back to all converters of the scope
+	reachable := make([]*registeredConverter, 0, len(available))
+
 	for _, reg := range available {
 		if current == reg.converter {
 			continue
@@ -144,41 +184,30 @@ func (l *lookupContext) LookUp(current Converter, targetType, sourceType types.T
 	}
 
 	ctx := &lookupContext{
+		scope:       l.scope,
 		converters:  reachable,
 		logger:      l.logger,
 		target:      l.target,
 		source:      l.source,
 		interceptor: l.interceptor,
+		returnError: l.returnError,
+		params:      l.params,
 	}
-	var nextContext []string
-	for _, converter := range ctx.converters {
-		nextContext = append(nextContext, converter.typ.Name())
+
+	cache := l.scope.lookUpCache()
+	var key lookUpKey
+	if cache != nil {
+		key = makeLookUpKey(targetType, sourceType, reachable, l.returnError, l.params)
 	}
 
-	if enableLookUpCache {
-		for _, c := range lkCache {
-			if TypeUtil.IsIdentical(targetType, c.target) && TypeUtil.IsIdentical(sourceType, c.source) {
-				for _, reg := range reachable {
-					if c.converter == reg.converter {
-						return c.converter, nil
-					}
-				}
-			}
-		}
+	converter, ok := cache.get(key)
+	if !ok {
+		converter = selectConverter(ctx, reachable, targetType, sourceType)
+		cache.put(key, converter)
 	}
 
-	for _, reg := range reachable {
-		LookUpTotalHits++
-		if reg.converter.CanConvert(ctx, targetType, sourceType) {
-			if enableLookUpCache {
-				lkCache = append(lkCache, lookUpCache{
-					converter: reg.converter,
-					target:    targetType,
-					source:    sourceType,
-				})
-			}
-			return wrapFieldInterceptor(reg.converter, l.interceptor), nil
-		}
+	if converter != nil {
+		return wrapFieldInterceptor(converter, l.interceptor), nil
 	}
 	return nil, fmt.Errorf("unable to find matching converter for target %s, source %s", targetType.String(), sourceType.String())
 }
@@ -191,6 +220,14 @@ func (l *lookupContext) SourceDescriptor() *Descriptor {
 	return &l.source
 }
 
+func (l *lookupContext) CanReturnError() bool {
+	return l.returnError
+}
+
+func (l *lookupContext) Param(name string) (Symbol, bool) {
+	return findParam(l.params, name)
+}
+
 func (l *lookupContext) Logger() *slog.Logger {
 	return l.logger
 }
@@ -227,19 +264,57 @@ func (w *wrappedConverter) ConvertField(ctx ConverterContext, target, source Sym
 
 var _ Converter = (*wrappedConverter)(nil)
 
-func findConverter(target, source Descriptor, logger *slog.Logger) (Converter, bool) {
-	for _, reg := range globalConverters {
-		LookUpTotalHits++
-		if reg.converter.CanConvert(newLookupContext(target, source, logger), target.structFieldInfo.Type, source.structFieldInfo.Type) {
-			if enableLookUpCache {
-				lkCache = append(lkCache, lookUpCache{
-					converter: reg.converter,
-					target:    target.structFieldInfo.Type,
-					source:    source.structFieldInfo.Type,
-				})
-			}
+func findConverter(scope *converterScope, target, source Descriptor, returnError bool, params []Symbol, logger *slog.Logger) (Converter, bool) {
+	targetType, sourceType := target.structFieldInfo.Type, source.structFieldInfo.Type
+	converters := scope.registered()
+
+	cache := scope.lookUpCache()
+	var key lookUpKey
+	if cache != nil {
+		key = makeLookUpKey(targetType, sourceType, converters, returnError, params)
+	}
+
+	converter, ok := cache.get(key)
+	if !ok {
+		lookup := newLookupContext(target, source, returnError, logger).withScope(scope).withParams(params)
+		converter = selectConverter(lookup, converters, targetType, sourceType)
+		cache.put(key, converter)
+	}
+	return converter, converter != nil
+}
+
+// findInterceptedConverter is findConverter for a field which has a field interceptor, the
+// interceptor decides whether a converter can convert the field, e.g. null-mode uses its own
+// mode. The result is not cached because it depends on the interceptor.
+func findInterceptedConverter(scope *converterScope, target, source Descriptor, returnError bool, params []Symbol, interceptor FieldInterceptor, logger *slog.Logger) (Converter, bool) {
+	targetType, sourceType := target.structFieldInfo.Type, source.structFieldInfo.Type
+	lookup := newLookupContext(target, source, returnError, logger).withScope(scope).withParams(params)
+	for _, reg := range scope.registered() {
+		atomic.AddUint64(&LookUpTotalHits, 1)
+		if interceptor.InterceptCanConvert(reg.converter, lookup, targetType, sourceType) {
 			return reg.converter, true
 		}
 	}
 	return nil, false
 }
+
+// selectConverter returns the first converter which can convert sourceType to targetType, or
+// nil if there is none.
+func selectConverter(ctx LookupContext, converters []*registeredConverter, targetType, sourceType types.Type) Converter {
+	for _, reg := range converters {
+		atomic.AddUint64(&LookUpTotalHits, 1)
+		if reg.converter.CanConvert(ctx, targetType, sourceType) {
+			return reg.converter
+		}
+	}
+	return nil
+}
+
+func findParam(params []Symbol, name string) (Symbol, bool) {
+	for _, param := range params {
+		if param.VarName == name {
+			return param, true
+		}
+	}
+	return Symbol{}, false
+}
@@ -0,0 +1,140 @@
+package gomappergen
+
+import (
+	"container/list"
+	"go/types"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultLookUpCacheSize is the number of lookup results a generator keeps unless
+// WithLookUpCacheSize is given.
+const DefaultLookUpCacheSize = 4096
+
+// lookUpKey identifies a lookup. The selected converter depends on the types, the converters
+// which are reachable, whether the mapper function returns an error and its extra parameters.
+// The field interceptor is not part of the key, it only wraps the selected converter.
+type lookUpKey struct {
+	target      string
+	source      string
+	converters  string
+	returnError bool
+	params      string
+}
+
+func makeLookUpKey(target, source types.Type, converters []*registeredConverter, returnError bool, params []Symbol) lookUpKey {
+	var ids strings.Builder
+	for _, reg := range converters {
+		ids.WriteString(strconv.Itoa(reg.id))
+		ids.WriteByte(',')
+	}
+
+	var ps strings.Builder
+	for _, param := range params {
+		ps.WriteString(param.VarName)
+		ps.WriteByte(' ')
+		ps.WriteString(types.TypeString(param.Type, nil))
+		ps.WriteByte(',')
+	}
+
+	return lookUpKey{
+		target:      types.TypeString(target, nil),
+		source:      types.TypeString(source, nil),
+		converters:  ids.String(),
+		returnError: returnError,
+		params:      ps.String(),
+	}
+}
+
+type lookUpEntry struct {
+	key lookUpKey
+
+	// converter is nil if no converter can convert the types
+	converter Converter
+}
+
+// lookUpCache is a least recently used cache of lookup results, it is safe for concurrent use.
+// A nil cache caches nothing.
+type lookUpCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[lookUpKey]*list.Element
+	order   *list.List
+
+	// hits and misses count the lookups which are answered by, or missed, the cache
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// newLookUpCache returns a cache which keeps up to size results, or nil if size is not positive.
+func newLookUpCache(size int) *lookUpCache {
+	if size <= 0 {
+		return nil
+	}
+	return &lookUpCache{
+		size:    size,
+		entries: make(map[lookUpKey]*list.Element),
+		order:   list.New(),
+	}
+}
+
+func (c *lookUpCache) get(key lookUpKey) (Converter, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	c.hits.Add(1)
+	c.order.MoveToFront(e)
+	return e.Value.(*lookUpEntry).converter, true
+}
+
+func (c *lookUpCache) put(key lookUpKey, converter Converter) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*lookUpEntry).converter = converter
+		c.order.MoveToFront(e)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lookUpEntry{key: key, converter: converter})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lookUpEntry).key)
+	}
+}
+
+// stats returns the number of hits and misses of the cache.
+func (c *lookUpCache) stats() (hits, misses uint64) {
+	if c == nil {
+		return 0, 0
+	}
+	return c.hits.Load(), c.misses.Load()
+}
+
+func (c *lookUpCache) len() int {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,102 @@
+package gomappergen
+
+import (
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_lookUpCache_evictsLeastRecentlyUsed(t *testing.T) {
+	cache := newLookUpCache(2)
+	a := makeLookUpKey(types.Typ[types.Int], types.Typ[types.String], nil, false, nil)
+	b := makeLookUpKey(types.Typ[types.Int], types.Typ[types.Int64], nil, false, nil)
+	c := makeLookUpKey(types.Typ[types.Int], types.Typ[types.Int32], nil, false, nil)
+
+	cache.put(a, BuiltinConverters.Numeric)
+	cache.put(b, nil)
+
+	_, ok := cache.get(a)
+	require.True(t, ok)
+
+	cache.put(c, BuiltinConverters.Numeric)
+	assert.Equal(t, 2, cache.len())
+
+	_, ok = cache.get(b)
+	assert.False(t, ok, "b is the least recently used result")
+
+	converter, ok := cache.get(a)
+	assert.True(t, ok)
+	assert.Same(t, BuiltinConverters.Numeric, converter)
+
+	hits, misses := cache.stats()
+	assert.Equal(t, uint64(2), hits)
+	assert.Equal(t, uint64(1), misses)
+}
+
+func Test_lookUpCache_nilCachesNothing(t *testing.T) {
+	var cache *lookUpCache
+	key := makeLookUpKey(types.Typ[types.Int], types.Typ[types.String], nil, false, nil)
+
+	cache.put(key, BuiltinConverters.Numeric)
+	_, ok := cache.get(key)
+	assert.False(t, ok)
+	assert.Nil(t, newLookUpCache(0))
+}
+
+func Test_makeLookUpKey(t *testing.T) {
+	regs := []*registeredConverter{{id: 0}, {id: 1}, {id: 2}}
+	key := makeLookUpKey(types.Typ[types.Int], types.Typ[types.String], regs, false, nil)
+
+	assert.Equal(t, key, makeLookUpKey(types.Typ[types.Int], types.Typ[types.String], regs, false, nil))
+	assert.NotEqual(t, key, makeLookUpKey(types.Typ[types.Int], types.Typ[types.String], regs[1:], false, nil))
+	assert.NotEqual(t, key, makeLookUpKey(types.Typ[types.Int], types.Typ[types.String], regs, true, nil))
+	assert.NotEqual(t, key, makeLookUpKey(types.Typ[types.String], types.Typ[types.Int], regs, false, nil))
+
+	params := []Symbol{{VarName: "loc", Type: types.Typ[types.String]}}
+	assert.NotEqual(t, key, makeLookUpKey(types.Typ[types.Int], types.Typ[types.String], regs, false, params))
+}
+
+func Test_lookupContext_LookUp_wrapsCachedConverterWithInterceptor(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register(&dummyConverter{})
+	registry.Register(BuiltinConverters.IdenticalType)
+	scope := newConverterScope(registry.snapshot(nil, Config{}, NewNoopLogger()), DefaultLookUpCacheSize)
+
+	lookup := emptyLookupContext(scope, NewNoopLogger())
+	converter, err := lookup.LookUp(BuiltinConverters.IdenticalType, types.Typ[types.Int], types.Typ[types.String])
+	require.NoError(t, err)
+	assert.IsType(t, &dummyConverter{}, converter)
+	assert.Equal(t, 1, scope.cache.len())
+
+	lookup.interceptor = &nilIfZeroFieldInterceptor{}
+	converter, err = lookup.LookUp(BuiltinConverters.IdenticalType, types.Typ[types.Int], types.Typ[types.String])
+	require.NoError(t, err)
+	assert.IsType(t, &wrappedConverter{}, converter)
+	assert.Equal(t, 1, scope.cache.len())
+
+	// the current converter is excluded, so the result of another lookup is not reused
+	_, err = lookup.LookUp(registry.registered()[0].converter, types.Typ[types.Int], types.Typ[types.String])
+	assert.Error(t, err)
+	assert.Equal(t, 2, scope.cache.len())
+}
+
+func Test_generatorImpl_Stats(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register(&dummyConverter{})
+	first := New(nil, Config{}, WithRegistry(registry))
+	second := New(nil, Config{}, WithRegistry(registry))
+
+	field := StructFieldInfo{Name: "ID", Type: types.Typ[types.Int]}
+	target := Descriptor{structFieldInfo: &field}
+	other := StructFieldInfo{Name: "ID", Type: types.Typ[types.String]}
+	source := Descriptor{structFieldInfo: &other}
+
+	scope := first.(*generatorImpl).scope
+	findConverter(scope, target, source, false, nil, NewNoopLogger())
+	findConverter(scope, target, source, false, nil, NewNoopLogger())
+
+	assert.Equal(t, GeneratorStats{LookUpCacheHits: 1, LookUpCacheMisses: 1}, first.Stats())
+	assert.Equal(t, GeneratorStats{}, second.Stats())
+}
@@ -246,7 +246,7 @@ func (o *StandardConversionOrchestrator) routing(c Converter, ctx LookupContext,
 		if o.isTypesMatch(expectedTarget, source) {
 			ac := o.findConverter(c, ctx, expectedSource, target)
 			if ac != nil {
-				return standardRouting{route: standardRouteSourceToTargetToOther, afterConverter: ac}
+				return standardRouting{route: standardRouteTargetToSourceToOther, afterConverter: ac}
 			}
 		}
 	}
@@ -354,8 +354,6 @@ var _ orchestrator = (*StandardConversionOrchestrator)(nil)
 // ```
 // The GeneratedTypeOrchestrator actually just a wrapper of StandardConversionOrchestrator.
 type GeneratedTypeOrchestrator struct {
-	wrapee *StandardConversionOrchestrator
-
 	Generated TypeInfo
 	Target    TypeInfo
 
@@ -365,18 +363,17 @@ type GeneratedTypeOrchestrator struct {
 	OtherToTargetToGenerated func(ctx ConverterContext, target, source Symbol, otherToTarget Converter) jen.Code
 }
 
+// toStandardConversionOrchestrator makes the wrapped orchestrator on every call, so a converter
+// can be used by packages which are generated concurrently.
 func (o *GeneratedTypeOrchestrator) toStandardConversionOrchestrator() *StandardConversionOrchestrator {
-	if o.wrapee == nil {
-		o.wrapee = &StandardConversionOrchestrator{
-			Source:                o.Generated,
-			Target:                o.Target,
-			SourceToTarget:        o.GeneratedToTarget,
-			SourceToTargetToOther: o.GeneratedToTargetToOther,
-			TargetToSource:        o.TargetToGenerated,
-			OtherToTargetToSource: o.OtherToTargetToGenerated,
-		}
+	return &StandardConversionOrchestrator{
+		Source:                o.Generated,
+		Target:                o.Target,
+		SourceToTarget:        o.GeneratedToTarget,
+		SourceToTargetToOther: o.GeneratedToTargetToOther,
+		TargetToSource:        o.TargetToGenerated,
+		OtherToTargetToSource: o.OtherToTargetToGenerated,
 	}
-	return o.wrapee
 }
 
 func (o *GeneratedTypeOrchestrator) CanConvert(c Converter, ctx LookupContext, targetType, sourceType types.Type) bool {
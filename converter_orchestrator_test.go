@@ -0,0 +1,29 @@
+package gomappergen
+
+import (
+	"go/types"
+	"testing"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStandardConversionOrchestrator_routing_targetToSourceToOther(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register(&dummyConverter{})
+	scope := newConverterScope(registry.snapshot(nil, Config{}, NewNoopLogger()), DefaultLookUpCacheSize)
+
+	emit := func(ctx ConverterContext, target, source Symbol, other Converter) jen.Code { return jen.Null() }
+	o := StandardConversionOrchestrator{
+		Source:                MakeTypeInfo(0),
+		Target:                MakeTypeInfo(""),
+		SourceToTargetToOther: emit,
+		TargetToSourceToOther: emit,
+	}
+
+	// string -> int -> int64 is B -> A -> T
+	routing := o.routing(BuiltinConverters.IdenticalType, emptyLookupContext(scope, NewNoopLogger()), types.Typ[types.String], types.Typ[types.Int64])
+
+	assert.Equal(t, standardRoute(standardRouteTargetToSourceToOther), routing.route)
+	assert.IsType(t, &dummyConverter{}, routing.afterConverter)
+}
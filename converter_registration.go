@@ -8,12 +8,228 @@ import (
 	"slices"
 	"sort"
 	"strconv"
+	"sync"
 
 	"github.com/IGLOU-EU/go-wildcard"
 	"github.com/toniphan21/go-mapper-gen/internal/util"
 )
 
-// RegisterConverter adds a new Converter to the global converter registry.
+// Registry is a set of converters which a generator selects from, use WithRegistry to make a
+// generator with it. The package level functions such as RegisterConverter use the
+// DefaultRegistry, which New uses if no registry is given.
+//
+// The generator takes a snapshot of the registry in New, so changing the registry later does
+// not affect generators which are already made. The snapshot initializes a copy of every
+// converter, so generators made with different configs do not share the converter state.
+type Registry struct {
+	mu         sync.Mutex
+	converters []*registeredConverter
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+var defaultRegistry = NewRegistry()
+
+// DefaultRegistry returns the registry used by the package level functions, and by New if
+// WithRegistry is not given.
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+// Register adds a new Converter to the registry, see RegisterConverter.
+func (r *Registry) Register(converter Converter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.converters = append(r.converters, &registeredConverter{
+		converter:     converter,
+		typ:           normalizeConverterType(converter),
+		qualifiedName: qualifiedName(converter),
+		priority:      len(r.converters) + 1,
+		builtIn:       false,
+	})
+}
+
+// RegisterBuiltin adds the built-in converters which are enabled in config to the registry.
+func (r *Registry) RegisterBuiltin(config BuiltInConverterConfig) {
+	priority := 0
+	if config.UseIdentical {
+		r.registerBuiltIn(BuiltinConverters.IdenticalType, 0)
+	}
+
+	if config.UseSlice {
+		r.registerBuiltIn(BuiltinConverters.Slice, priority)
+		priority++
+	}
+
+	if config.UseTypeToPointer {
+		r.registerBuiltIn(BuiltinConverters.TypeToPointer, priority)
+		priority++
+	}
+
+	if config.UsePointerToType {
+		r.registerBuiltIn(BuiltinConverters.PointerToType, priority)
+		priority++
+	}
+
+	if config.UseNumeric {
+		r.registerBuiltIn(BuiltinConverters.Numeric, priority)
+		priority++
+	}
+
+	if config.UseFunctions {
+		r.registerBuiltIn(BuiltinConverters.Functions, priority)
+		priority++
+	}
+
+	if config.UseTime {
+		r.registerBuiltIn(BuiltinConverters.Time, priority)
+		priority++
+	}
+
+	if config.UseStrconv {
+		r.registerBuiltIn(BuiltinConverters.Strconv, priority)
+		priority++
+	}
+
+	if config.UseText {
+		r.registerBuiltIn(BuiltinConverters.Text, priority)
+		priority++
+	}
+
+	if config.UseDiscovery {
+		r.registerBuiltIn(BuiltinConverters.Discovery, priority)
+		priority++
+	}
+}
+
+// RegisterAllBuiltin adds all built-in converters to the registry.
+func (r *Registry) RegisterAllBuiltin() {
+	cf := BuiltInConverterConfig{}
+	cf.EnableAll()
+
+	r.RegisterBuiltin(cf)
+}
+
+// Clear removes all converters from the registry.
+func (r *Registry) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.converters = []*registeredConverter{}
+}
+
+// Print logs the converters of the registry in the order of their priorities.
+func (r *Registry) Print(logger *slog.Logger) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	shortFormBuffer := 0
+	for _, c := range r.converters {
+		info := c.converter.Info()
+		l := len(info.ShortForm)
+		if l > shortFormBuffer {
+			shortFormBuffer = l
+		}
+	}
+
+	for _, v := range r.converters {
+		builtin := ""
+		if v.builtIn {
+			builtin = util.ColorCyan("[built-in]")
+		}
+
+		info := v.converter.Info()
+		line := fmt.Sprintf("%-*s %v", shortFormBuffer+1, info.ShortForm, info.ShortFormDescription)
+
+		logger.Info(
+			fmt.Sprintf("%s %10s %s",
+				util.ColorBlue(fmt.Sprintf("%5s", strconv.Itoa(v.priority))),
+				builtin,
+				line,
+			),
+		)
+	}
+}
+
+func (r *Registry) registerBuiltIn(converter Converter, priority int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.converters = append(r.converters, &registeredConverter{
+		converter:     converter,
+		typ:           normalizeConverterType(converter),
+		qualifiedName: qualifiedName(converter),
+		priority:      priority,
+		builtIn:       true,
+	})
+
+	slices.SortFunc(r.converters, func(a, b *registeredConverter) int {
+		return a.priority - b.priority
+	})
+}
+
+// prioritize orders the converters by parsedConfig.ConverterPriorities.
+func prioritize(converters []*registeredConverter, parsedConfig Config) {
+	var prioritied = getOrderConverterQualifiedNamesByPrioritiesConfig(converters, parsedConfig.ConverterPriorities)
+	for i, v := range prioritied {
+		for _, reg := range converters {
+			if reg.qualifiedName == v {
+				reg.priority = i
+			}
+		}
+	}
+
+	slices.SortFunc(converters, func(a, b *registeredConverter) int {
+		return a.priority - b.priority
+	})
+}
+
+// snapshot returns a prioritized copy of the registry with copies of the converters
+// initialized, so converters registered later are not used by the generator and Init of
+// another generator does not change them. The registry itself is not changed.
+func (r *Registry) snapshot(parser Parser, parsedConfig Config, logger *slog.Logger) *Registry {
+	r.mu.Lock()
+	converters := make([]*registeredConverter, len(r.converters))
+	for i, v := range r.converters {
+		reg := *v
+		converters[i] = &reg
+	}
+	r.mu.Unlock()
+
+	prioritize(converters, parsedConfig)
+	for i, reg := range converters {
+		reg.id = i
+		reg.converter = copyConverter(reg.converter)
+		reg.converter.Init(parser, parsedConfig, logger)
+	}
+	return &Registry{converters: converters}
+}
+
+// copyConverter returns a shallow copy of a converter which is a pointer of a struct, other
+// converters are returned as is.
+func copyConverter(converter Converter) Converter {
+	v := reflect.ValueOf(converter)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return converter
+	}
+
+	c := reflect.New(v.Elem().Type())
+	c.Elem().Set(v.Elem())
+	return c.Interface().(Converter)
+}
+
+// registered returns the converters of the registry, they must not be changed.
+func (r *Registry) registered() []*registeredConverter {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.converters
+}
+
+// RegisterConverter adds a new Converter to the default converter registry.
 //
 // Converters are selected by the mapper generator based on:
 //  1. Whether they report true from CanConvert(...)
@@ -37,16 +253,12 @@ import (
 // Passing the same converter instance multiple times is allowed but generally
 // discouraged unless intentional.
 func RegisterConverter(converter Converter) {
-	globalConverters = append(globalConverters, &registeredConverter{
-		converter:     converter,
-		typ:           normalizeConverterType(converter),
-		qualifiedName: qualifiedName(converter),
-		priority:      len(globalConverters) + 1,
-		builtIn:       false,
-	})
+	defaultRegistry.Register(converter)
 }
 
 type registeredConverter struct {
+	// id is the index of the converter in the snapshot of a registry, see lookUpKey
+	id            int
 	converter     Converter
 	typ           reflect.Type
 	priority      int
@@ -54,8 +266,6 @@ type registeredConverter struct {
 	qualifiedName string
 }
 
-var globalConverters []*registeredConverter
-
 func normalizeConverterType(v Converter) reflect.Type {
 	if v == nil {
 		return nil
@@ -139,112 +349,24 @@ func getOrderConverterQualifiedNamesByPrioritiesConfig(regs []*registeredConvert
 	return result
 }
 
-func prioritizeRegisteredConverters(parsedConfig Config) {
-	var prioritied = getOrderConverterQualifiedNamesByPrioritiesConfig(globalConverters, parsedConfig.ConverterPriorities)
-	for i, v := range prioritied {
-		for _, reg := range globalConverters {
-			if reg.qualifiedName == v {
-				reg.priority = i
-			}
-		}
-	}
-
-	slices.SortFunc(globalConverters, func(a, b *registeredConverter) int {
-		return a.priority - b.priority
-	})
-}
-
-func initRegisteredConverters(parser Parser, parsedConfig Config, logger *slog.Logger) {
-	prioritizeRegisteredConverters(parsedConfig)
-	for _, v := range globalConverters {
-		v.converter.Init(parser, parsedConfig, logger)
-	}
-}
-
-func registerBuiltInConverter(converter Converter, priority int) {
-	globalConverters = append(globalConverters, &registeredConverter{
-		converter:     converter,
-		typ:           normalizeConverterType(converter),
-		qualifiedName: qualifiedName(converter),
-		priority:      priority,
-		builtIn:       true,
-	})
-
-	slices.SortFunc(globalConverters, func(a, b *registeredConverter) int {
-		return a.priority - b.priority
-	})
-}
-
 func PrintRegisteredConverters(logger *slog.Logger) {
-	shortFormBuffer := 0
-	for _, c := range globalConverters {
-		info := c.converter.Info()
-		l := len(info.ShortForm)
-		if l > shortFormBuffer {
-			shortFormBuffer = l
-		}
-	}
-
-	for _, v := range globalConverters {
-		builtin := ""
-		if v.builtIn {
-			builtin = util.ColorCyan("[built-in]")
-		}
-
-		info := v.converter.Info()
-		line := fmt.Sprintf("%-*s %v", shortFormBuffer+1, info.ShortForm, info.ShortFormDescription)
-
-		logger.Info(
-			fmt.Sprintf("%s %10s %s",
-				util.ColorBlue(fmt.Sprintf("%5s", strconv.Itoa(v.priority))),
-				builtin,
-				line,
-			),
-		)
-	}
+	defaultRegistry.Print(logger)
 }
 
 func ClearAllRegisteredConverters() {
-	globalConverters = []*registeredConverter{}
+	defaultRegistry.Clear()
 }
 
 func RegisterAllBuiltinConverters() {
-	cf := BuiltInConverterConfig{}
-	cf.EnableAll()
-
-	RegisterBuiltinConverters(cf)
+	defaultRegistry.RegisterAllBuiltin()
 }
 
 func RegisterBuiltinConverters(config BuiltInConverterConfig) {
-	priority := 0
-	if config.UseIdentical {
-		registerBuiltInConverter(BuiltinConverters.IdenticalType, 0)
-	}
-
-	if config.UseSlice {
-		registerBuiltInConverter(BuiltinConverters.Slice, priority)
-		priority++
-	}
-
-	if config.UseTypeToPointer {
-		registerBuiltInConverter(BuiltinConverters.TypeToPointer, priority)
-		priority++
-	}
-
-	if config.UsePointerToType {
-		registerBuiltInConverter(BuiltinConverters.PointerToType, priority)
-		priority++
-	}
-
-	if config.UseNumeric {
-		registerBuiltInConverter(BuiltinConverters.Numeric, priority)
-		priority++
-	}
+	defaultRegistry.RegisterBuiltin(config)
+}
 
-	if config.UseFunctions {
-		registerBuiltInConverter(BuiltinConverters.Functions, priority)
-		priority++
-	}
+func registerBuiltInConverter(converter Converter, priority int) {
+	defaultRegistry.registerBuiltIn(converter, priority)
 }
 
 type builtinConverters struct {
@@ -254,6 +376,10 @@ type builtinConverters struct {
 	PointerToType Converter
 	Numeric       Converter
 	Functions     Converter
+	Time          Converter
+	Strconv       Converter
+	Text          Converter
+	Discovery     Converter
 }
 
 var BuiltinConverters = builtinConverters{
@@ -263,4 +389,8 @@ var BuiltinConverters = builtinConverters{
 	PointerToType: &pointerToTypeConverter{},
 	Numeric:       &numericConverter{},
 	Functions:     &functionsConverter{},
+	Time:          &timeConverter{},
+	Strconv:       &strconvConverter{},
+	Text:          &textConverter{},
+	Discovery:     &discoveryConverter{},
 }
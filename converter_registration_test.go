@@ -1,7 +1,11 @@
 package gomappergen
 
 import (
+	"context"
+	"fmt"
+	"go/types"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -79,3 +83,80 @@ func Test_matchConverterPriority(t *testing.T) {
 		})
 	}
 }
+
+func TestRegistry_generatorsDoNotShareRegisteredConverters(t *testing.T) {
+	dummy := &dummyConverter{}
+	first := NewRegistry()
+	first.Register(dummy)
+
+	second := NewRegistry()
+	second.Register(BuiltinConverters.IdenticalType)
+
+	firstScope := newConverterScope(first.snapshot(nil, Config{}, NewNoopLogger()), DefaultLookUpCacheSize)
+	secondScope := newConverterScope(second.snapshot(nil, Config{}, NewNoopLogger()), DefaultLookUpCacheSize)
+
+	// changing a registry does not affect the generators made with it
+	first.Clear()
+	first.Register(BuiltinConverters.IdenticalType)
+
+	field := StructFieldInfo{Name: "ID", Type: types.Typ[types.Int]}
+	target := Descriptor{structFieldInfo: &field}
+	other := StructFieldInfo{Name: "ID", Type: types.Typ[types.String]}
+	source := Descriptor{structFieldInfo: &other}
+
+	converter, ok := findConverter(firstScope, target, source, false, nil, NewNoopLogger())
+	assert.True(t, ok)
+	assert.IsType(t, dummy, converter)
+
+	_, ok = findConverter(secondScope, target, source, false, nil, NewNoopLogger())
+	assert.False(t, ok)
+}
+
+func TestRegistry_snapshotDoesNotChangeRegistry(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register(BuiltinConverters.IdenticalType)
+	registry.Register(&dummyConverter{})
+
+	names := func(regs []*registeredConverter) []string {
+		var result []string
+		for _, reg := range regs {
+			result = append(result, reg.qualifiedName)
+		}
+		return result
+	}
+	identical, dummy := qualifiedName(BuiltinConverters.IdenticalType), qualifiedName(&dummyConverter{})
+	before := names(registry.registered())
+	priorities := make([]int, len(registry.registered()))
+	for i, reg := range registry.registered() {
+		priorities[i] = reg.priority
+	}
+
+	dummyFirst := registry.snapshot(nil, Config{ConverterPriorities: []string{dummy, identical}}, NewNoopLogger())
+	identicalFirst := registry.snapshot(nil, Config{ConverterPriorities: []string{identical, dummy}}, NewNoopLogger())
+
+	assert.Equal(t, []string{dummy, identical}, names(dummyFirst.registered()))
+	assert.Equal(t, []string{identical, dummy}, names(identicalFirst.registered()))
+	assert.Equal(t, before, names(registry.registered()))
+	for i, reg := range registry.registered() {
+		assert.Equal(t, priorities[i], reg.priority)
+	}
+}
+
+func TestRegistry_generatorsWithDifferentConfigsDoNotShareConverters(t *testing.T) {
+	registry := NewRegistry()
+	registry.RegisterBuiltin(BuiltInConverterConfig{UseTime: true})
+
+	first := New(nil, Config{TimeConverter: TimeConverterConfig{Layout: "2006-01-02"}}, WithRegistry(registry))
+	second := New(nil, Config{TimeConverter: TimeConverterConfig{Layout: "15:04"}}, WithRegistry(registry))
+
+	convert := func(g Generator) string {
+		converter := g.(*generatorImpl).scope.registered()[0].converter
+		ctx := &converterContext{Context: context.Background(), lookupContext: emptyLookupContext(nil, NewNoopLogger())}
+		target := Symbol{VarName: "out", Type: types.Typ[types.String]}
+		source := Symbol{VarName: "in", Type: MakeTypeInfo(time.Time{}).ToType()}
+		return fmt.Sprintf("%#v", converter.ConvertField(ctx, target, source))
+	}
+
+	assert.Equal(t, `out = in.Format("2006-01-02")`, convert(first))
+	assert.Equal(t, `out = in.Format("15:04")`, convert(second))
+}
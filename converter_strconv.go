@@ -0,0 +1,183 @@
+package gomappergen
+
+import (
+	"fmt"
+	"go/types"
+	"log/slog"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// strconvConverter converts numeric and bool types from/to string using strconv. Parsing
+// a string is only possible if the mapper returns an error, or the parse mode is must or
+// zero.
+type strconvConverter struct {
+	parseMode ParseMode
+}
+
+func (c *strconvConverter) Init(_ Parser, config Config, _ *slog.Logger) {
+	c.parseMode = config.StrconvConverter.Parse
+}
+
+func (c *strconvConverter) Info() ConverterInfo {
+	return ConverterInfo{
+		Name:                 "built-in strconvConverter",
+		ShortForm:            "[T number|bool] <-> string",
+		ShortFormDescription: "Format/parse number and bool types via strconv",
+	}
+}
+
+func (c *strconvConverter) CanConvert(ctx LookupContext, targetType, sourceType types.Type) bool {
+	if c.isString(targetType) {
+		return c.isSupported(sourceType)
+	}
+
+	if c.isString(sourceType) && c.isSupported(targetType) {
+		return c.parseMode != ParseModeError || GeneratorUtil.CanReturnError(ctx)
+	}
+	return false
+}
+
+func (c *strconvConverter) ConvertField(ctx ConverterContext, target, source Symbol) jen.Code {
+	return ctx.Run(c, func() jen.Code {
+		if c.isString(target.Type) {
+			return c.format(target, source)
+		}
+		return c.parse(ctx, target, source)
+	})
+}
+
+func (c *strconvConverter) format(target, source Symbol) jen.Code {
+	/** generated code:
+	{target} = strconv.Itoa({source})
+
+	// or depends on source's type
+	{target} = strconv.FormatInt(int64({source}), 10)
+	{target} = strconv.FormatUint(uint64({source}), 10)
+	{target} = strconv.FormatFloat(float64({source}), 'f', -1, 64)
+	{target} = strconv.FormatBool({source})
+	*/
+	basic := source.Type.Underlying().(*types.Basic)
+	value := func(kind types.BasicKind) jen.Code {
+		return c.cast(types.Typ[kind], source.Type, source.Expr())
+	}
+
+	var call jen.Code
+	switch {
+	case basic.Kind() == types.Int:
+		call = jen.Qual("strconv", "Itoa").Call(value(types.Int))
+
+	case basic.Info()&types.IsUnsigned != 0:
+		call = jen.Qual("strconv", "FormatUint").Call(value(types.Uint64), jen.Lit(10))
+
+	case basic.Info()&types.IsInteger != 0:
+		call = jen.Qual("strconv", "FormatInt").Call(value(types.Int64), jen.Lit(10))
+
+	case basic.Info()&types.IsFloat != 0:
+		call = jen.Qual("strconv", "FormatFloat").Call(value(types.Float64), jen.LitRune('f'), jen.Lit(-1), jen.Lit(c.bitSize(basic)))
+
+	default:
+		call = jen.Qual("strconv", "FormatBool").Call(value(types.Bool))
+	}
+	return target.Expr().Op("=").Add(c.cast(target.Type, types.Typ[types.String], call))
+}
+
+func (c *strconvConverter) parse(ctx ConverterContext, target, source Symbol) jen.Code {
+	/** generated code:
+	v0, err := strconv.Atoi({source})
+	if err != nil {
+		return ..., err // or panic(err) if parse mode is must
+	}
+	{target} = v0
+
+	// or if parse mode is zero
+	if v0, err := strconv.Atoi({source}); err == nil {
+		{target} = v0
+	}
+
+	// the function depends on target's type
+	strconv.ParseInt({source}, 10, 32)
+	strconv.ParseUint({source}, 10, 8)
+	strconv.ParseFloat({source}, 64)
+	strconv.ParseBool({source})
+	*/
+	basic := target.Type.Underlying().(*types.Basic)
+	value := c.cast(types.Typ[types.String], source.Type, source.Expr())
+
+	var call jen.Code
+	var resultType types.Type
+	switch {
+	case basic.Kind() == types.Int:
+		call = jen.Qual("strconv", "Atoi").Call(value)
+		resultType = types.Typ[types.Int]
+
+	case basic.Info()&types.IsUnsigned != 0:
+		call = jen.Qual("strconv", "ParseUint").Call(value, jen.Lit(10), jen.Lit(c.bitSize(basic)))
+		resultType = types.Typ[types.Uint64]
+
+	case basic.Info()&types.IsInteger != 0:
+		call = jen.Qual("strconv", "ParseInt").Call(value, jen.Lit(10), jen.Lit(c.bitSize(basic)))
+		resultType = types.Typ[types.Int64]
+
+	case basic.Info()&types.IsFloat != 0:
+		call = jen.Qual("strconv", "ParseFloat").Call(value, jen.Lit(c.bitSize(basic)))
+		resultType = types.Typ[types.Float64]
+
+	default:
+		call = jen.Qual("strconv", "ParseBool").Call(value)
+		resultType = types.Typ[types.Bool]
+	}
+
+	return GeneratorUtil.parseCode(ctx, c.parseMode, call, func(v jen.Code) jen.Code {
+		return target.Expr().Op("=").Add(c.cast(target.Type, resultType, v))
+	})
+}
+
+// cast converts value of type from to type to if they are not identical.
+func (c *strconvConverter) cast(to, from types.Type, value jen.Code) jen.Code {
+	if TypeUtil.IsIdentical(to, from) {
+		return value
+	}
+	return jen.Add(GeneratorUtil.TypeToJenCode(to)).Call(value)
+}
+
+func (c *strconvConverter) bitSize(basic *types.Basic) int {
+	switch basic.Kind() {
+	case types.Int8, types.Uint8:
+		return 8
+	case types.Int16, types.Uint16:
+		return 16
+	case types.Int32, types.Uint32, types.Float32:
+		return 32
+	case types.Uint, types.Uintptr:
+		return 0
+	default:
+		return 64
+	}
+}
+
+// RoundTripLoss implements RoundTripLossReporter, formatting a parsed string only keeps its
+// canonical form.
+func (c *strconvConverter) RoundTripLoss(targetType, sourceType types.Type) string {
+	if !c.isString(sourceType) {
+		return ""
+	}
+	return fmt.Sprintf("parsed as %s by strconv, a string which is not in the canonical format is changed, e.g. 01 becomes 1", targetType.String())
+}
+
+func (c *strconvConverter) isString(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsString != 0
+}
+
+func (c *strconvConverter) isSupported(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+	if !ok {
+		return false
+	}
+
+	const supported = types.IsInteger | types.IsFloat | types.IsBoolean
+	return basic.Info()&supported != 0 && basic.Info()&types.IsUntyped == 0
+}
+
+var _ RoundTripLossReporter = (*strconvConverter)(nil)
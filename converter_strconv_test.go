@@ -0,0 +1,137 @@
+package gomappergen
+
+import (
+	"testing"
+)
+
+func Test_strconvConverter(t *testing.T) {
+	errorMode := &Config{StrconvConverter: StrconvConverterConfig{Parse: ParseModeError}}
+	mustMode := &Config{StrconvConverter: StrconvConverterConfig{Parse: ParseModeMust}}
+	zeroMode := &Config{StrconvConverter: StrconvConverterConfig{Parse: ParseModeZero}}
+	strconvImport := []string{`import "strconv"`}
+
+	cases := []ConverterTestCase{
+		{Name: "cannot convert string to complex128", Config: zeroMode, SourceType: "string", TargetType: "complex128"},
+		{Name: "cannot convert string to int without returning error", Config: errorMode, SourceType: "string", TargetType: "int"},
+		{Name: "cannot convert []byte to string", Config: zeroMode, SourceType: "[]byte", TargetType: "string"},
+
+		{
+			Name:               "int to string",
+			Config:             errorMode,
+			SourceType:         "int",
+			TargetType:         "string",
+			ExpectedCanConvert: true,
+			ExpectedImports:    strconvImport,
+			ExpectedCode:       []string{"out.targetField = strconv.Itoa(in.sourceField)"},
+		},
+
+		{
+			Name:               "int32 to string",
+			Config:             errorMode,
+			SourceType:         "int32",
+			TargetType:         "string",
+			ExpectedCanConvert: true,
+			ExpectedImports:    strconvImport,
+			ExpectedCode:       []string{"out.targetField = strconv.FormatInt(int64(in.sourceField), 10)"},
+		},
+
+		{
+			Name:               "uint8 to string",
+			Config:             errorMode,
+			SourceType:         "uint8",
+			TargetType:         "string",
+			ExpectedCanConvert: true,
+			ExpectedImports:    strconvImport,
+			ExpectedCode:       []string{"out.targetField = strconv.FormatUint(uint64(in.sourceField), 10)"},
+		},
+
+		{
+			Name:               "float32 to string",
+			Config:             errorMode,
+			SourceType:         "float32",
+			TargetType:         "string",
+			ExpectedCanConvert: true,
+			ExpectedImports:    strconvImport,
+			ExpectedCode:       []string{"out.targetField = strconv.FormatFloat(float64(in.sourceField), 'f', -1, 32)"},
+		},
+
+		{
+			Name:               "bool to string",
+			Config:             errorMode,
+			SourceType:         "bool",
+			TargetType:         "string",
+			ExpectedCanConvert: true,
+			ExpectedImports:    strconvImport,
+			ExpectedCode:       []string{"out.targetField = strconv.FormatBool(in.sourceField)"},
+		},
+
+		{
+			Name:               "string to int64, parse mode error",
+			Config:             errorMode,
+			SourceType:         "string",
+			TargetType:         "int64",
+			ReturnError:        true,
+			ExpectedCanConvert: true,
+			ExpectedImports:    strconvImport,
+			ExpectedCode: []string{
+				"v0, err := strconv.ParseInt(in.sourceField, 10, 64)",
+				"if err != nil {",
+				"	return err",
+				"}",
+				"out.targetField = v0",
+			},
+		},
+
+		{
+			Name:               "string to uint16, parse mode must",
+			Config:             mustMode,
+			SourceType:         "string",
+			TargetType:         "uint16",
+			ExpectedCanConvert: true,
+			ExpectedImports:    strconvImport,
+			ExpectedCode: []string{
+				"v0, err := strconv.ParseUint(in.sourceField, 10, 16)",
+				"if err != nil {",
+				"	panic(err)",
+				"}",
+				"out.targetField = uint16(v0)",
+			},
+		},
+
+		{
+			Name:               "string to float32, parse mode zero",
+			Config:             zeroMode,
+			SourceType:         "string",
+			TargetType:         "float32",
+			ExpectedCanConvert: true,
+			ExpectedImports:    strconvImport,
+			ExpectedCode: []string{
+				"if v0, err := strconv.ParseFloat(in.sourceField, 32); err == nil {",
+				"	out.targetField = float32(v0)",
+				"}",
+			},
+		},
+
+		{
+			Name:               "string to bool, parse mode zero",
+			Config:             zeroMode,
+			SourceType:         "string",
+			TargetType:         "bool",
+			ExpectedCanConvert: true,
+			ExpectedImports:    strconvImport,
+			ExpectedCode: []string{
+				"if v0, err := strconv.ParseBool(in.sourceField); err == nil {",
+				"	out.targetField = v0",
+				"}",
+			},
+		},
+		// ---
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			converter := &strconvConverter{}
+			Test.RunConverterTestCase(t, tc, converter)
+		})
+	}
+}
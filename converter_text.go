@@ -0,0 +1,201 @@
+package gomappergen
+
+import (
+	"go/types"
+	"log/slog"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// textConverter converts a type from/to string or []byte using its methods: String(),
+// MarshalText()/UnmarshalText() from encoding.TextMarshaler/TextUnmarshaler, and
+// MarshalBinary()/UnmarshalBinary() from encoding.BinaryMarshaler/BinaryUnmarshaler.
+// Except String(), the methods return an error so they are only used when the mapper
+// returns an error.
+type textConverter struct {
+	bytesType types.Type
+	errorType types.Type
+}
+
+func (c *textConverter) Init(_ Parser, _ Config, _ *slog.Logger) {
+	c.bytesType = types.NewSlice(types.Universe.Lookup("byte").Type())
+	c.errorType = types.Universe.Lookup("error").Type()
+}
+
+func (c *textConverter) Info() ConverterInfo {
+	return ConverterInfo{
+		Name:                 "built-in textConverter",
+		ShortForm:            "[T Stringer|TextMarshaler|BinaryMarshaler] <-> string|[]byte",
+		ShortFormDescription: "Convert via String(), MarshalText()/UnmarshalText(), MarshalBinary()/UnmarshalBinary()",
+	}
+}
+
+func (c *textConverter) CanConvert(ctx LookupContext, targetType, sourceType types.Type) bool {
+	return c.methodName(ctx, targetType, sourceType) != ""
+}
+
+func (c *textConverter) ConvertField(ctx ConverterContext, target, source Symbol) jen.Code {
+	return ctx.Run(c, func() jen.Code {
+		switch method := c.methodName(ctx, target.Type, source.Type); method {
+		case "":
+			return nil
+
+		case "String":
+			/** generated code:
+			{target} = {source}.String()
+			*/
+			value := jen.Add(source.Expr()).Dot("String").Call()
+			return target.Expr().Op("=").Add(c.cast(target.Type, types.Typ[types.String], value))
+
+		case "MarshalText", "MarshalBinary":
+			/** generated code:
+			v0, err := {source}.MarshalText()
+			if err != nil {
+				return ..., err
+			}
+			{target} = string(v0)
+			*/
+			call := jen.Add(source.Expr()).Dot(method).Call()
+			return GeneratorUtil.parseCode(ctx, ParseModeError, call, func(v jen.Code) jen.Code {
+				return target.Expr().Op("=").Add(c.cast(target.Type, c.bytesType, v))
+			})
+
+		default:
+			/** generated code:
+			var v0 T
+			if err := v0.UnmarshalText([]byte({source})); err != nil {
+				return ..., err
+			}
+			{target} = v0
+			*/
+			varName := ctx.NextVarName()
+			code := jen.Line().Var().Id(varName).Add(GeneratorUtil.TypeToJenCode(target.Type)).Line()
+
+			arg := c.cast(c.bytesType, source.Type, source.Expr())
+			code = code.If(
+				jen.Err().Op(":=").Id(varName).Dot(method).Call(arg),
+				jen.Err().Op("!=").Nil(),
+			).Block(GeneratorUtil.ReturnError(ctx, jen.Err())).Line()
+			return code.Add(target.Expr()).Op("=").Id(varName)
+		}
+	})
+}
+
+// methodName returns the name of the method used to convert sourceType to targetType, or
+// an empty string if there is no suitable method.
+func (c *textConverter) methodName(ctx LookupContext, targetType, sourceType types.Type) string {
+	if c.isString(targetType) {
+		if c.hasMethod(sourceType, "String", nil, []types.Type{types.Typ[types.String]}) {
+			return "String"
+		}
+
+		if name := c.firstMarshalMethod(ctx, sourceType, "MarshalText"); name != "" {
+			return name
+		}
+	}
+
+	if c.isBytes(targetType) {
+		if name := c.firstMarshalMethod(ctx, sourceType, "MarshalBinary", "MarshalText"); name != "" {
+			return name
+		}
+	}
+
+	if c.isString(sourceType) {
+		if name := c.firstUnmarshalMethod(ctx, targetType, "UnmarshalText"); name != "" {
+			return name
+		}
+	}
+
+	if c.isBytes(sourceType) {
+		return c.firstUnmarshalMethod(ctx, targetType, "UnmarshalBinary", "UnmarshalText")
+	}
+	return ""
+}
+
+func (c *textConverter) firstMarshalMethod(ctx LookupContext, t types.Type, names ...string) string {
+	if !GeneratorUtil.CanReturnError(ctx) {
+		return ""
+	}
+
+	for _, name := range names {
+		if c.hasMethod(t, name, nil, []types.Type{c.bytesType, c.errorType}) {
+			return name
+		}
+	}
+	return ""
+}
+
+func (c *textConverter) firstUnmarshalMethod(ctx LookupContext, t types.Type, names ...string) string {
+	if _, ok := t.(*types.Pointer); ok || !GeneratorUtil.CanReturnError(ctx) {
+		return ""
+	}
+
+	// the Unmarshal methods have pointer receivers, so they are looked up in *T's method set
+	for _, name := range names {
+		if c.hasMethod(types.NewPointer(t), name, []types.Type{c.bytesType}, []types.Type{c.errorType}) {
+			return name
+		}
+	}
+	return ""
+}
+
+// hasMethod reports whether t's method set contains a method with the given name and
+// signature, interface types are not supported.
+func (c *textConverter) hasMethod(t types.Type, name string, params, results []types.Type) bool {
+	if TypeUtil.IsInterface(t) {
+		return false
+	}
+
+	methodSet := types.NewMethodSet(t)
+	for i := 0; i < methodSet.Len(); i++ {
+		fn, ok := methodSet.At(i).Obj().(*types.Func)
+		if !ok || fn.Name() != name {
+			continue
+		}
+
+		sig, ok := fn.Type().(*types.Signature)
+		if !ok {
+			return false
+		}
+		return c.matchTuple(sig.Params(), params) && c.matchTuple(sig.Results(), results)
+	}
+	return false
+}
+
+func (c *textConverter) matchTuple(tuple *types.Tuple, expected []types.Type) bool {
+	if tuple.Len() != len(expected) {
+		return false
+	}
+
+	for i, v := range expected {
+		if !types.Identical(tuple.At(i).Type(), v) {
+			return false
+		}
+	}
+	return true
+}
+
+// cast converts value of type from to type to if they are not identical.
+func (c *textConverter) cast(to, from types.Type, value jen.Code) jen.Code {
+	if TypeUtil.IsIdentical(to, from) {
+		return value
+	}
+	return jen.Add(GeneratorUtil.TypeToJenCode(to)).Call(value)
+}
+
+func (c *textConverter) isString(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Kind() == types.String
+}
+
+func (c *textConverter) isBytes(t types.Type) bool {
+	slice, ok := t.Underlying().(*types.Slice)
+	if !ok {
+		return false
+	}
+
+	basic, ok := slice.Elem().(*types.Basic)
+	return ok && basic.Kind() == types.Byte
+}
+
+var _ Converter = (*textConverter)(nil)
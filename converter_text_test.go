@@ -0,0 +1,125 @@
+package gomappergen
+
+import "testing"
+
+func Test_textConverter(t *testing.T) {
+	additionalCode := []string{
+		`type Email string`,
+		``,
+		`func (e Email) String() string { return string(e) }`,
+		``,
+		`type Money struct{ Cents int64 }`,
+		``,
+		`func (m Money) MarshalText() ([]byte, error) { return nil, nil }`,
+		``,
+		`func (m *Money) UnmarshalText(b []byte) error { return nil }`,
+		``,
+		`type Key [4]byte`,
+		``,
+		`func (k Key) MarshalBinary() ([]byte, error) { return k[:], nil }`,
+		``,
+		`func (k *Key) UnmarshalBinary(b []byte) error { return nil }`,
+		``,
+		`type Invalid struct{}`,
+		``,
+		`func (i Invalid) String() int { return 0 }`,
+		``,
+	}
+	config := &Config{}
+
+	cases := []ConverterTestCase{
+		{Name: "cannot convert Invalid to string", AdditionalCode: additionalCode, Config: config, SourceType: "Invalid", TargetType: "string"},
+		{Name: "cannot convert Money to string without returning error", AdditionalCode: additionalCode, Config: config, SourceType: "Money", TargetType: "string"},
+		{Name: "cannot convert string to Money without returning error", AdditionalCode: additionalCode, Config: config, SourceType: "string", TargetType: "Money"},
+		{Name: "cannot convert string to *Money", AdditionalCode: additionalCode, Config: config, SourceType: "string", TargetType: "*Money", ReturnError: true},
+		{Name: "cannot convert string to Email", AdditionalCode: additionalCode, Config: config, SourceType: "string", TargetType: "Email", ReturnError: true},
+
+		{
+			Name:               "Email to string via String()",
+			AdditionalCode:     additionalCode,
+			Config:             config,
+			SourceType:         "Email",
+			TargetType:         "string",
+			ExpectedCanConvert: true,
+			ExpectedCode:       []string{"out.targetField = in.sourceField.String()"},
+		},
+
+		{
+			Name:               "Money to string via MarshalText()",
+			AdditionalCode:     additionalCode,
+			Config:             config,
+			SourceType:         "Money",
+			TargetType:         "string",
+			ReturnError:        true,
+			ExpectedCanConvert: true,
+			ExpectedCode: []string{
+				"v0, err := in.sourceField.MarshalText()",
+				"if err != nil {",
+				"	return err",
+				"}",
+				"out.targetField = string(v0)",
+			},
+		},
+
+		{
+			Name:               "string to Money via UnmarshalText()",
+			AdditionalCode:     additionalCode,
+			Config:             config,
+			SourceType:         "string",
+			TargetType:         "Money",
+			ReturnError:        true,
+			ExpectedCanConvert: true,
+			ExpectedCode: []string{
+				"",
+				"var v0 Money",
+				"if err := v0.UnmarshalText([]byte(in.sourceField)); err != nil {",
+				"	return err",
+				"}",
+				"out.targetField = v0",
+			},
+		},
+
+		{
+			Name:               "Key to []byte via MarshalBinary()",
+			AdditionalCode:     additionalCode,
+			Config:             config,
+			SourceType:         "Key",
+			TargetType:         "[]byte",
+			ReturnError:        true,
+			ExpectedCanConvert: true,
+			ExpectedCode: []string{
+				"v0, err := in.sourceField.MarshalBinary()",
+				"if err != nil {",
+				"	return err",
+				"}",
+				"out.targetField = v0",
+			},
+		},
+
+		{
+			Name:               "[]byte to Key via UnmarshalBinary()",
+			AdditionalCode:     additionalCode,
+			Config:             config,
+			SourceType:         "[]byte",
+			TargetType:         "Key",
+			ReturnError:        true,
+			ExpectedCanConvert: true,
+			ExpectedCode: []string{
+				"",
+				"var v0 Key",
+				"if err := v0.UnmarshalBinary(in.sourceField); err != nil {",
+				"	return err",
+				"}",
+				"out.targetField = v0",
+			},
+		},
+		// ---
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			converter := &textConverter{}
+			Test.RunConverterTestCase(t, tc, converter)
+		})
+	}
+}
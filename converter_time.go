@@ -0,0 +1,281 @@
+package gomappergen
+
+import (
+	"fmt"
+	"go/types"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// timeConvertFunc emits the code which assigns the converted value to the target.
+type timeConvertFunc func(ctx ConverterContext, target Symbol, value jen.Code) jen.Code
+
+// timeConverter converts time.Time from/to string using the configured layout, and
+// from/to int64 as an Unix timestamp in the configured unit. Both conversions can be
+// combined with other converters, e.g. pgtype.Timestamptz -> time.Time -> string or
+// *string -> string -> time.Time.
+type timeConverter struct {
+	layout    string
+	unit      TimeUnit
+	parseMode ParseMode
+
+	formatOrchestrator StandardConversionOrchestrator
+	parseOrchestrator  StandardConversionOrchestrator
+	unixOrchestrator   StandardConversionOrchestrator
+}
+
+func (c *timeConverter) Init(_ Parser, config Config, _ *slog.Logger) {
+	c.layout = config.TimeConverter.Layout
+	if c.layout == "" {
+		c.layout = time.RFC3339
+	}
+	c.unit = config.TimeConverter.Unit
+	c.parseMode = config.TimeConverter.Parse
+
+	timeInfo := MakeTypeInfo(time.Time{})
+	timeType := timeInfo.ToType()
+	stringInfo := MakeTypeInfo("")
+	stringType := stringInfo.ToType()
+	int64Info := MakeTypeInfo(int64(0))
+	int64Type := int64Info.ToType()
+
+	c.formatOrchestrator = StandardConversionOrchestrator{
+		Source:                timeInfo,
+		Target:                stringInfo,
+		SourceToTarget:        c.direct(c.format),
+		OtherToSourceToTarget: c.otherToDirect(timeType, c.format),
+		SourceToTargetToOther: c.directToOther(stringType, c.format),
+	}
+
+	c.parseOrchestrator = StandardConversionOrchestrator{
+		Source:                stringInfo,
+		Target:                timeInfo,
+		SourceToTarget:        c.direct(c.parse),
+		OtherToSourceToTarget: c.otherToDirect(stringType, c.parse),
+		SourceToTargetToOther: c.directToOther(timeType, c.parse),
+	}
+
+	c.unixOrchestrator = StandardConversionOrchestrator{
+		Source:                timeInfo,
+		Target:                int64Info,
+		SourceToTarget:        c.direct(c.toUnix),
+		OtherToSourceToTarget: c.otherToDirect(timeType, c.toUnix),
+		SourceToTargetToOther: c.directToOther(int64Type, c.toUnix),
+		TargetToSource:        c.direct(c.fromUnix),
+		OtherToTargetToSource: c.otherToDirect(int64Type, c.fromUnix),
+		TargetToSourceToOther: c.directToOther(timeType, c.fromUnix),
+	}
+}
+
+func (c *timeConverter) Info() ConverterInfo {
+	return ConverterInfo{
+		Name:                 "built-in timeConverter",
+		ShortForm:            "time.Time <-> [T string|int64]",
+		ShortFormDescription: "time.Time to T where string -> T or int64 -> T is possible, via layout or Unix timestamp",
+	}
+}
+
+func (c *timeConverter) CanConvert(ctx LookupContext, targetType, sourceType types.Type) bool {
+	return c.findOrchestrator(ctx, targetType, sourceType) != nil
+}
+
+func (c *timeConverter) ConvertField(ctx ConverterContext, target, source Symbol) jen.Code {
+	return ctx.Run(c, func() jen.Code {
+		o := c.findOrchestrator(ctx, target.Type, source.Type)
+		if o == nil {
+			return nil
+		}
+		return o.PerformConvert(c, ctx, target, source)
+	})
+}
+
+// findOrchestrator returns the orchestrator which can convert sourceType to targetType,
+// parsing a string is only possible if the parse error can be handled.
+func (c *timeConverter) findOrchestrator(ctx LookupContext, targetType, sourceType types.Type) *StandardConversionOrchestrator {
+	// a number is always an Unix timestamp, the layout is not used because a formatted time
+	// cannot be parsed as a number, e.g. time.Time -> string -> int64
+	if c.isNumber(targetType) || c.isNumber(sourceType) {
+		if c.unixOrchestrator.CanConvert(c, ctx, targetType, sourceType) {
+			return &c.unixOrchestrator
+		}
+		return nil
+	}
+
+	if c.formatOrchestrator.CanConvert(c, ctx, targetType, sourceType) {
+		return &c.formatOrchestrator
+	}
+
+	if c.parseMode != ParseModeError || GeneratorUtil.CanReturnError(ctx) {
+		if c.parseOrchestrator.CanConvert(c, ctx, targetType, sourceType) {
+			return &c.parseOrchestrator
+		}
+	}
+
+	if c.unixOrchestrator.CanConvert(c, ctx, targetType, sourceType) {
+		return &c.unixOrchestrator
+	}
+	return nil
+}
+
+// isNumber reports whether t is a numeric type or a pointer of it.
+func (c *timeConverter) isNumber(t types.Type) bool {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsNumeric != 0
+}
+
+// RoundTripLoss implements RoundTripLossReporter, a Unix timestamp or a formatted time only
+// keeps a part of the time.
+func (c *timeConverter) RoundTripLoss(targetType, sourceType types.Type) string {
+	if c.isNumber(targetType) {
+		if c.unit == TimeUnitNanosecond {
+			return "Unix timestamp in nanoseconds, the location is lost"
+		}
+		return fmt.Sprintf("Unix timestamp in %s, the precision below a %s and the location are lost", c.unitName(), strings.TrimSuffix(c.unitName(), "s"))
+	}
+
+	if c.isNumber(sourceType) {
+		return ""
+	}
+
+	if c.isString(targetType) {
+		return fmt.Sprintf("formatted with the layout %q, the parts of the time which the layout does not include are lost", c.layout)
+	}
+	return fmt.Sprintf("parsed with the layout %q, a string which is not formatted exactly by the layout is changed", c.layout)
+}
+
+func (c *timeConverter) unitName() string {
+	switch c.unit {
+	case TimeUnitMillisecond:
+		return "milliseconds"
+	case TimeUnitMicrosecond:
+		return "microseconds"
+	case TimeUnitNanosecond:
+		return "nanoseconds"
+	default:
+		return "seconds"
+	}
+}
+
+// isString reports whether t is a string type or a pointer of it.
+func (c *timeConverter) isString(t types.Type) bool {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsString != 0
+}
+
+func (c *timeConverter) direct(convert timeConvertFunc) func(ctx ConverterContext, target, source Symbol) jen.Code {
+	return func(ctx ConverterContext, target, source Symbol) jen.Code {
+		return convert(ctx, target, source.Expr())
+	}
+}
+
+func (c *timeConverter) otherToDirect(typ types.Type, convert timeConvertFunc) func(ctx ConverterContext, target, source Symbol, oc Converter) jen.Code {
+	return func(ctx ConverterContext, target, source Symbol, oc Converter) jen.Code {
+		/** generated code:
+		var v0 T
+		// other converter converts source to v0
+
+		// convert v0 to target
+		*/
+		varName := ctx.NextVarName()
+		code := jen.Line().Var().Id(varName).Add(GeneratorUtil.TypeToJenCode(typ)).Line()
+
+		targetSymbol := Symbol{VarName: varName, Type: typ, Metadata: SymbolMetadata{IsVariable: true, HasZeroValue: true}}
+		convertedCode := oc.ConvertField(ctx, targetSymbol, source)
+		if convertedCode == nil {
+			return nil
+		}
+		code.Add(convertedCode).Line()
+
+		return code.Add(convert(ctx, target, jen.Id(varName)))
+	}
+}
+
+func (c *timeConverter) directToOther(typ types.Type, convert timeConvertFunc) func(ctx ConverterContext, target, source Symbol, oc Converter) jen.Code {
+	return func(ctx ConverterContext, target, source Symbol, oc Converter) jen.Code {
+		/** generated code:
+		var v0 T
+		// convert source to v0
+
+		// other converter converts v0 to target
+		*/
+		varName := ctx.NextVarName()
+		code := jen.Line().Var().Id(varName).Add(GeneratorUtil.TypeToJenCode(typ)).Line()
+
+		tempSymbol := Symbol{VarName: varName, Type: typ, Metadata: SymbolMetadata{IsVariable: true, HasZeroValue: true}}
+		code.Add(convert(ctx, tempSymbol, source.Expr())).Line()
+
+		convertedCode := oc.ConvertField(ctx, target, Symbol{VarName: varName, Type: typ})
+		if convertedCode == nil {
+			return nil
+		}
+		return code.Add(convertedCode).Line()
+	}
+}
+
+func (c *timeConverter) format(_ ConverterContext, target Symbol, value jen.Code) jen.Code {
+	/** generated code:
+	{target} = {value}.Format("2006-01-02T15:04:05Z07:00")
+	*/
+	return target.Expr().Op("=").Add(value).Dot("Format").Call(jen.Lit(c.layout))
+}
+
+func (c *timeConverter) parse(ctx ConverterContext, target Symbol, value jen.Code) jen.Code {
+	/** generated code:
+	v0, err := time.Parse("2006-01-02T15:04:05Z07:00", {value})
+	if err != nil {
+		return ..., err // or panic(err) if parse mode is must
+	}
+	{target} = v0
+	*/
+	call := jen.Qual("time", "Parse").Call(jen.Lit(c.layout), value)
+	return GeneratorUtil.parseCode(ctx, c.parseMode, call, func(v jen.Code) jen.Code {
+		return target.Expr().Op("=").Add(v)
+	})
+}
+
+func (c *timeConverter) toUnix(_ ConverterContext, target Symbol, value jen.Code) jen.Code {
+	/** generated code:
+	{target} = {value}.Unix() // or UnixMilli(), UnixMicro(), UnixNano() depends on unit
+	*/
+	method := "Unix"
+	switch c.unit {
+	case TimeUnitMillisecond:
+		method = "UnixMilli"
+	case TimeUnitMicrosecond:
+		method = "UnixMicro"
+	case TimeUnitNanosecond:
+		method = "UnixNano"
+	}
+	return target.Expr().Op("=").Add(value).Dot(method).Call()
+}
+
+func (c *timeConverter) fromUnix(_ ConverterContext, target Symbol, value jen.Code) jen.Code {
+	/** generated code:
+	{target} = time.Unix({value}, 0) // or time.UnixMilli(v), time.UnixMicro(v), time.Unix(0, v) depends on unit
+	*/
+	var rhs jen.Code
+	switch c.unit {
+	case TimeUnitMillisecond:
+		rhs = jen.Qual("time", "UnixMilli").Call(value)
+	case TimeUnitMicrosecond:
+		rhs = jen.Qual("time", "UnixMicro").Call(value)
+	case TimeUnitNanosecond:
+		rhs = jen.Qual("time", "Unix").Call(jen.Lit(0), value)
+	default:
+		rhs = jen.Qual("time", "Unix").Call(value, jen.Lit(0))
+	}
+	return target.Expr().Op("=").Add(rhs)
+}
+
+var _ RoundTripLossReporter = (*timeConverter)(nil)
@@ -0,0 +1,71 @@
+package gomappergen
+
+import (
+	"testing"
+)
+
+func Test_timeConverter(t *testing.T) {
+	config := &Config{
+		TimeConverter:    TimeConverterConfig{Parse: ParseModeError},
+		StrconvConverter: StrconvConverterConfig{Parse: ParseModeError},
+	}
+	timeImport := map[string]string{"time": "time"}
+
+	cases := []ConverterTestCase{
+		{
+			Name:               "time.Time to int64 is an Unix timestamp with strconv enabled",
+			Config:             config,
+			Imports:            timeImport,
+			SourceType:         "time.Time",
+			TargetType:         "int64",
+			ReturnError:        true,
+			ExpectedCanConvert: true,
+			ExpectedCode:       []string{"out.targetField = in.sourceField.Unix()"},
+		},
+
+		{
+			Name:               "int64 to time.Time is an Unix timestamp with strconv enabled",
+			Config:             config,
+			Imports:            timeImport,
+			SourceType:         "int64",
+			TargetType:         "time.Time",
+			ReturnError:        true,
+			ExpectedCanConvert: true,
+			ExpectedImports:    []string{`import "time"`},
+			ExpectedCode:       []string{"out.targetField = time.Unix(in.sourceField, 0)"},
+		},
+
+		{
+			Name:               "time.Time to string uses the layout with strconv enabled",
+			Config:             config,
+			Imports:            timeImport,
+			SourceType:         "time.Time",
+			TargetType:         "string",
+			ReturnError:        true,
+			ExpectedCanConvert: true,
+			ExpectedCode:       []string{`out.targetField = in.sourceField.Format("2006-01-02T15:04:05Z07:00")`},
+		},
+
+		{
+			Name:        "cannot convert time.Time to float64 via a formatted string",
+			Config:      config,
+			Imports:     timeImport,
+			SourceType:  "time.Time",
+			TargetType:  "float64",
+			ReturnError: true,
+		},
+		// ---
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			strconv := &strconvConverter{}
+			strconv.Init(nil, *tc.Config, NewNoopLogger())
+			registerBuiltInConverter(&identicalTypeConverter{}, 0)
+			registerBuiltInConverter(strconv, 1)
+
+			converter := &timeConverter{}
+			Test.RunConverterTestCase(t, tc, converter)
+		})
+	}
+}
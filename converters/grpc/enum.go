@@ -0,0 +1,301 @@
+package grpc
+
+import (
+	"go/constant"
+	"go/types"
+	"log/slog"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/dave/jennifer/jen"
+	gen "github.com/toniphan21/go-mapper-gen"
+)
+
+type enumValue struct {
+	constName     string
+	matchName     string
+	value         constant.Value
+	isUnspecified bool
+}
+
+type enumInfo struct {
+	typ      types.Type
+	pkgPath  string
+	typeName string
+	isProto  bool
+	values   []enumValue
+}
+
+type enumPair struct {
+	proto  enumValue
+	domain enumValue
+}
+
+type enumConverter struct {
+	parser gen.Parser
+
+	mu    sync.Mutex
+	enums map[string]*enumInfo
+}
+
+func (c *enumConverter) Init(parser gen.Parser, _ gen.Config, _ *slog.Logger) {
+	c.parser = parser
+	c.enums = make(map[string]*enumInfo)
+}
+
+func (c *enumConverter) Info() gen.ConverterInfo {
+	return gen.ConverterInfo{
+		Name:                 "built-in lib grpc/enumConverter",
+		ShortForm:            "[protobuf enum] <-> [T enum]",
+		ShortFormDescription: "protobuf enum to T string/int enum, matched by constant name; UNSPECIFIED by default",
+	}
+}
+
+func (c *enumConverter) CanConvert(ctx gen.LookupContext, targetType, sourceType types.Type) bool {
+	_, _, ok := c.matchEnums(targetType, sourceType)
+	return ok
+}
+
+func (c *enumConverter) ConvertField(ctx gen.ConverterContext, target, source gen.Symbol) jen.Code {
+	return ctx.Run(c, func() jen.Code {
+		proto, domain, ok := c.matchEnums(target.Type, source.Type)
+		if !ok {
+			return nil
+		}
+
+		if gen.TypeUtil.IsIdentical(proto.typ, source.Type) {
+			return c.protoToDomain(target, source, proto, domain)
+		}
+		return c.domainToProto(target, source, proto, domain)
+	})
+}
+
+// protoToDomain handles protobuf enum -> T, the zero value (UNSPECIFIED) or any
+// unknown value falls back to the matched domain constant or the zero value.
+func (c *enumConverter) protoToDomain(target, source gen.Symbol, proto, domain *enumInfo) jen.Code {
+	/** generated code:
+	switch {source} {
+	case pb.Status_STATUS_ACTIVE:
+		{target} = StatusActive
+	default:
+		{target} = StatusUnspecified
+	}
+	*/
+	pairs := c.pairs(proto, domain)
+
+	var fallback *enumValue
+	for _, p := range pairs {
+		if p.proto.isUnspecified {
+			fallback = &p.domain
+			break
+		}
+	}
+
+	return jen.Switch(source.Expr()).BlockFunc(func(g *jen.Group) {
+		seen := make(map[string]bool)
+		for _, p := range pairs {
+			key := p.proto.value.ExactString()
+			if p.proto.isUnspecified || seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			g.Case(jen.Qual(proto.pkgPath, p.proto.constName)).Block(
+				target.Expr().Op("=").Qual(domain.pkgPath, p.domain.constName),
+			)
+		}
+
+		switch {
+		case fallback != nil:
+			g.Default().Block(target.Expr().Op("=").Qual(domain.pkgPath, fallback.constName))
+
+		case !target.Metadata.HasZeroValue:
+			g.Default().BlockFunc(func(g *jen.Group) {
+				gc := g.Var().Id("zero").Add(gen.GeneratorUtil.TypeToJenCode(target.Type)).Line()
+				gc.Add(target.Expr()).Op("=").Id("zero")
+			})
+		}
+	})
+}
+
+// domainToProto handles T -> protobuf enum, any unknown value falls back to
+// the zero value of the protobuf enum which is UNSPECIFIED by convention.
+func (c *enumConverter) domainToProto(target, source gen.Symbol, proto, domain *enumInfo) jen.Code {
+	/** generated code:
+	switch {source} {
+	case StatusActive:
+		{target} = pb.Status_STATUS_ACTIVE
+	default:
+		{target} = pb.Status_STATUS_UNSPECIFIED
+	}
+	*/
+	pairs := c.pairs(proto, domain)
+
+	var fallback *enumValue
+	for _, v := range proto.values {
+		if v.isUnspecified {
+			fallback = &v
+			break
+		}
+	}
+
+	return jen.Switch(source.Expr()).BlockFunc(func(g *jen.Group) {
+		seen := make(map[string]bool)
+		for _, p := range pairs {
+			key := p.domain.value.ExactString()
+			if p.proto.isUnspecified || seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			g.Case(jen.Qual(domain.pkgPath, p.domain.constName)).Block(
+				target.Expr().Op("=").Qual(proto.pkgPath, p.proto.constName),
+			)
+		}
+
+		if fallback != nil {
+			g.Default().Block(target.Expr().Op("=").Qual(proto.pkgPath, fallback.constName))
+		}
+	})
+}
+
+// matchEnums returns the protobuf enum and the domain enum if one side is a
+// protobuf enum, the other is a plain enum, and at least one constant matches.
+func (c *enumConverter) matchEnums(targetType, sourceType types.Type) (*enumInfo, *enumInfo, bool) {
+	source := c.findEnum(sourceType)
+	if source == nil {
+		return nil, nil, false
+	}
+
+	target := c.findEnum(targetType)
+	if target == nil {
+		return nil, nil, false
+	}
+
+	var proto, domain *enumInfo
+	switch {
+	case source.isProto && !target.isProto:
+		proto, domain = source, target
+	case !source.isProto && target.isProto:
+		proto, domain = target, source
+	default:
+		return nil, nil, false
+	}
+
+	if len(c.pairs(proto, domain)) == 0 {
+		return nil, nil, false
+	}
+	return proto, domain, true
+}
+
+func (c *enumConverter) pairs(proto, domain *enumInfo) []enumPair {
+	var result []enumPair
+	for _, pv := range proto.values {
+		for _, dv := range domain.values {
+			if pv.matchName == dv.matchName {
+				result = append(result, enumPair{proto: pv, domain: dv})
+				break
+			}
+		}
+	}
+	return result
+}
+
+func (c *enumConverter) findEnum(t types.Type) *enumInfo {
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return nil
+	}
+
+	pkgPath := named.Obj().Pkg().Path()
+	typeName := named.Obj().Name()
+	key := pkgPath + "." + typeName
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if v, ok := c.enums[key]; ok {
+		return v
+	}
+
+	var result *enumInfo
+	if parser, ok := c.parser.(gen.ExtendedParser); ok {
+		if info, ok := parser.FindEnum(pkgPath, typeName); ok {
+			result = c.makeEnumInfo(named, info)
+		}
+	}
+	c.enums[key] = result
+	return result
+}
+
+func (c *enumConverter) makeEnumInfo(named *types.Named, info gen.EnumInfo) *enumInfo {
+	typeName := named.Obj().Name()
+	result := &enumInfo{
+		typ:      named,
+		pkgPath:  named.Obj().Pkg().Path(),
+		typeName: typeName,
+		isProto:  c.isProtoEnum(named),
+	}
+
+	for _, v := range info.Values {
+		var matchName string
+		if result.isProto {
+			// protoc-gen-go names constants {GoTypeName}_{VALUE_NAME}, the value
+			// name is usually prefixed by the enum name in UPPER_SNAKE_CASE
+			valueName := strings.TrimPrefix(v.Name, typeName+"_")
+			shortName := typeName[strings.LastIndex(typeName, "_")+1:]
+			matchName = strings.TrimPrefix(valueName, toUpperSnakeCase(shortName)+"_")
+		} else {
+			matchName = strings.TrimPrefix(v.Name, typeName)
+		}
+
+		result.values = append(result.values, enumValue{
+			constName:     v.Name,
+			matchName:     normalizeEnumName(matchName),
+			value:         v.Value,
+			isUnspecified: result.isProto && constant.Sign(v.Value) == 0,
+		})
+	}
+	return result
+}
+
+// isProtoEnum reports whether the type is an int32 enum generated by protoc-gen-go
+// which always declares the {Type}_name and {Type}_value lookup maps next to it.
+func (c *enumConverter) isProtoEnum(named *types.Named) bool {
+	basic, ok := named.Underlying().(*types.Basic)
+	if !ok || basic.Kind() != types.Int32 {
+		return false
+	}
+
+	scope := named.Obj().Pkg().Scope()
+	typeName := named.Obj().Name()
+	_, hasName := scope.Lookup(typeName + "_name").(*types.Var)
+	_, hasValue := scope.Lookup(typeName + "_value").(*types.Var)
+	return hasName && hasValue
+}
+
+func normalizeEnumName(s string) string {
+	return strings.ToLower(strings.ReplaceAll(s, "_", ""))
+}
+
+// toUpperSnakeCase converts a CamelCase name to UPPER_SNAKE_CASE, keeping
+// acronyms together: HTTPStatus -> HTTP_STATUS.
+func toUpperSnakeCase(s string) string {
+	runes := []rune(s)
+
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				b.WriteRune('_')
+			}
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
+
+var _ gen.Converter = (*enumConverter)(nil)
@@ -19,6 +19,7 @@ func TestGolden(t *testing.T) {
 		printDiff   bool
 	}{
 		{file: "features/timestamp.md"},
+		{file: "features/enum.md"},
 	}
 
 	for _, tc := range cases {
@@ -4,12 +4,15 @@ import gen "github.com/toniphan21/go-mapper-gen"
 
 func RegisterConverters() {
 	gen.RegisterConverter(Converters.Timestamp)
+	gen.RegisterConverter(Converters.Enum)
 }
 
 type converters struct {
 	Timestamp gen.Converter
+	Enum      gen.Converter
 }
 
 var Converters = converters{
 	Timestamp: &timestampConverter{},
+	Enum:      &enumConverter{},
 }
@@ -6,10 +6,13 @@ import (
 
 	"github.com/dave/jennifer/jen"
 	gen "github.com/toniphan21/go-mapper-gen"
+	"github.com/toniphan21/go-mapper-gen/internal/nullable"
 )
 
 const pgtypePkgPath = "github.com/jackc/pgx/v5/pgtype"
 
+// baseConverter converts a nullable pgtype type T from/to V, which is a pointer of its value type.
+// The generated code is shared with other nullable library types, see package nullable.
 type baseConverter[T, V any] struct {
 	ValuePropertyName string
 	ValidPropertyName *string
@@ -20,25 +23,15 @@ type baseConverter[T, V any] struct {
 
 	generatedType T
 	targetType    V
-	orchestrator  gen.GeneratedTypeOrchestrator
-
-	targetedType types.Type
-	pgtypeName   string
+	nullable      *nullable.Type
+	nullMode      gen.NullMode
 }
 
-func (b *baseConverter[T, V]) Init(_ gen.Parser, _ gen.Config, _ *slog.Logger) {
+func (b *baseConverter[T, V]) Init(_ gen.Parser, config gen.Config, _ *slog.Logger) {
 	generated := gen.MakeTypeInfo(b.generatedType)
 	target := gen.MakeTypeInfo(b.targetType)
-	b.pgtypeName = generated.TypeName
-	b.targetedType = target.ToType()
-	b.orchestrator = gen.GeneratedTypeOrchestrator{
-		Generated:                generated,
-		Target:                   target,
-		GeneratedToTarget:        b.pgtypeToTarget,
-		GeneratedToTargetToOther: b.pgtypeToTargetToOther,
-		TargetToGenerated:        b.targetToPgType,
-		OtherToTargetToGenerated: b.otherToTargetToPGType,
-	}
+	b.nullable = nullable.New(generated, target, b.ValuePropertyName, b.ValidPropertyName)
+	b.nullMode = config.LibraryConverters.NullMode
 }
 
 func (b *baseConverter[T, V]) Info() gen.ConverterInfo {
@@ -50,119 +43,21 @@ func (b *baseConverter[T, V]) Info() gen.ConverterInfo {
 }
 
 func (b *baseConverter[T, V]) CanConvert(ctx gen.LookupContext, targetType, sourceType types.Type) bool {
-	return b.orchestrator.CanConvert(b, ctx, targetType, sourceType)
+	return b.nullable.CanConvert(b, ctx, b.nullMode, targetType, sourceType)
 }
 
 func (b *baseConverter[T, V]) ConvertField(ctx gen.ConverterContext, target, source gen.Symbol) jen.Code {
 	return ctx.Run(b, func() jen.Code {
-		return b.orchestrator.PerformConvert(b, ctx, target, source)
-	})
-}
-
-func (b *baseConverter[T, V]) pgtypeToTarget(ctx gen.ConverterContext, target, source gen.Symbol) jen.Code {
-	/** generated code:
-	if {source}.[[ValidPropertyName]] {
-		{target} = &{source}.[[ValuePropertyName]]
-	}
-	*/
-	validName := "Valid"
-	if b.ValidPropertyName != nil {
-		validName = *b.ValidPropertyName
-	}
-
-	return jen.If(source.Expr().Dot(validName)).BlockFunc(func(g *jen.Group) {
-		g.Add(target.Expr()).Op("=").Op("&").Add(source.Expr().Dot(b.ValuePropertyName))
+		return b.nullable.Convert(b, ctx, b.nullMode, target, source)
 	})
 }
 
-func (b *baseConverter[T, V]) pgtypeToTargetToOther(ctx gen.ConverterContext, target, source gen.Symbol, oc gen.Converter) jen.Code {
-	/** generated code:
-	var v0 [[Target]]
-	if {source}.[[ValidPropertyName]] {
-		v0 = &{source}.[[ValuePropertyName]]
-	}
-
-	// other converter converts v0 to target
-	*/
-	validName := "Valid"
-	if b.ValidPropertyName != nil {
-		validName = *b.ValidPropertyName
-	}
-
-	// first convert b.Generated to b.Target hold in a temporary variable
-	varName := ctx.NextVarName()
-	code := jen.Line().Var().Id(varName).Add(gen.GeneratorUtil.TypeToJenCode(b.targetedType)).Line()
-
-	code = code.If(source.Expr().Dot(validName)).BlockFunc(func(g *jen.Group) {
-		g.Add(jen.Id(varName)).Op("=").Op("&").Add(source.Expr().Dot(b.ValuePropertyName))
-	}).Line()
-
-	// then call other converter to convert the variable to target
-	sourceSymbol := gen.Symbol{VarName: varName, Type: b.targetedType}
-	convertedCode := oc.ConvertField(ctx, target, sourceSymbol)
-	if convertedCode == nil {
-		return nil
-	}
-	return code.Add(convertedCode).Line()
+func (b *baseConverter[T, V]) CanConvertWithNullMode(ctx gen.LookupContext, mode gen.NullMode, targetType, sourceType types.Type) bool {
+	return b.nullable.CanConvert(b, ctx, mode, targetType, sourceType)
 }
 
-func (b *baseConverter[T, V]) targetToPgType(ctx gen.ConverterContext, target, source gen.Symbol) jen.Code {
-	/** generated code:
-	if {source} != nil {
-		{target} = pgtype.[[pgtypeName]]{[[ValuePropertyName]]: *{source}, [[ValidPropertyName]]: true}
-	}
-	*/
-	validName := "Valid"
-	if b.ValidPropertyName != nil {
-		validName = *b.ValidPropertyName
-	}
-
-	code := jen.If(source.Expr().Op("!=").Nil())
-	code = code.BlockFunc(func(g *jen.Group) {
-		g.Add(target.Expr()).Op("=").Add(
-			jen.Qual(pgtypePkgPath, b.pgtypeName).Values(jen.DictFunc(func(d jen.Dict) {
-				d[jen.Id(b.ValuePropertyName)] = jen.Op("*").Add(source.Expr())
-				d[jen.Id(validName)] = jen.Lit(true)
-			})),
-		)
-	})
-
-	return code
-}
-
-func (b *baseConverter[T, V]) otherToTargetToPGType(ctx gen.ConverterContext, target, source gen.Symbol, oc gen.Converter) jen.Code {
-	/** generated code:
-	var v0 [[Target]]
-	// other converter converts source to v0
-
-	if v0 != nil {
-		{target} = pgtype.[[pgtypeName]]{[[ValuePropertyName]]: *v0, [[ValidPropertyName]]: true}
-	}
-	*/
-	validName := "Valid"
-	if b.ValidPropertyName != nil {
-		validName = *b.ValidPropertyName
-	}
-
-	// first convert source to [[Target]] hold in a temporary variable
-	varName := ctx.NextVarName()
-	code := jen.Line().Var().Id(varName).Add(gen.GeneratorUtil.TypeToJenCode(b.targetedType)).Line()
-	targetSymbol := gen.Symbol{VarName: varName, Type: b.targetedType, Metadata: gen.SymbolMetadata{IsVariable: true, HasZeroValue: true}}
-	convertedCode := oc.ConvertField(ctx, targetSymbol, source)
-	if convertedCode == nil {
-		return nil
-	}
-	code.Add(convertedCode).Line()
-
-	// then convert variable to target
-	code = code.Add(jen.If(jen.Id(varName).Op("!=").Nil()))
-	code = code.BlockFunc(func(g *jen.Group) {
-		g.Add(target.Expr()).Op("=").Add(
-			jen.Qual(pgtypePkgPath, b.pgtypeName).Values(jen.DictFunc(func(d jen.Dict) {
-				d[jen.Id(b.ValuePropertyName)] = jen.Op("*").Add(jen.Id(varName))
-				d[jen.Id(validName)] = jen.Lit(true)
-			})),
-		)
+func (b *baseConverter[T, V]) ConvertFieldWithNullMode(ctx gen.ConverterContext, mode gen.NullMode, target, source gen.Symbol) jen.Code {
+	return ctx.Run(b, func() jen.Code {
+		return b.nullable.Convert(b, ctx, mode, target, source)
 	})
-	return code
 }
@@ -31,6 +31,10 @@ func TestGolden(t *testing.T) {
 		{file: "testdata/time.md"},
 		{file: "testdata/timestamp.md"},
 		{file: "testdata/timestamptz.md"},
+		{file: "testdata/uuid.md"},
+		{file: "testdata/numeric.md"},
+		{file: "testdata/interval.md"},
+		{file: "testdata/range.md"},
 	}
 
 	for _, tc := range cases {
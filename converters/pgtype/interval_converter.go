@@ -0,0 +1,76 @@
+package pgtype
+
+import (
+	"go/types"
+	"log/slog"
+
+	"github.com/dave/jennifer/jen"
+	gen "github.com/toniphan21/go-mapper-gen"
+)
+
+// intervalConverter converts pgtype.Interval from/to time.Duration or *time.Duration.
+// Same as pgx, a month is considered as 30 days.
+type intervalConverter struct{}
+
+func (c *intervalConverter) Init(_ gen.Parser, _ gen.Config, _ *slog.Logger) {
+	// no-op
+}
+
+func (c *intervalConverter) Info() gen.ConverterInfo {
+	return gen.ConverterInfo{
+		Name:                 "built-in lib pgtype.Converter.Interval",
+		ShortForm:            "pgtype.Interval <-> [T time.Duration]",
+		ShortFormDescription: "pgtype.Interval to time.Duration or *time.Duration, a month is 30 days",
+	}
+}
+
+func (c *intervalConverter) CanConvert(ctx gen.LookupContext, targetType, sourceType types.Type) bool {
+	if isPgtype(sourceType, "Interval") {
+		return c.isDuration(elemType(targetType))
+	}
+
+	if isPgtype(targetType, "Interval") {
+		return c.isDuration(elemType(sourceType))
+	}
+	return false
+}
+
+func (c *intervalConverter) ConvertField(ctx gen.ConverterContext, target, source gen.Symbol) jen.Code {
+	return ctx.Run(c, func() jen.Code {
+		if isPgtype(source.Type, "Interval") {
+			/** generated code:
+			if {source}.Valid {
+				{target} = time.Duration({source}.Months)*30*24*time.Hour + time.Duration({source}.Days)*24*time.Hour + time.Duration({source}.Microseconds)*time.Microsecond
+			}
+			*/
+			duration := func(field string) *jen.Statement {
+				return jen.Qual("time", "Duration").Call(source.Expr().Dot(field))
+			}
+
+			value := duration("Months").Op("*").Lit(30).Op("*").Lit(24).Op("*").Qual("time", "Hour").
+				Op("+").Add(duration("Days")).Op("*").Lit(24).Op("*").Qual("time", "Hour").
+				Op("+").Add(duration("Microseconds")).Op("*").Qual("time", "Microsecond")
+
+			return jen.If(source.Expr().Dot("Valid")).BlockFunc(func(g *jen.Group) {
+				assignValue(ctx, g, target, value)
+			})
+		}
+
+		/** generated code:
+		{target} = pgtype.Interval{Microseconds: {source}.Microseconds(), Valid: true}
+		*/
+		// Microseconds() can be called on both time.Duration and *time.Duration
+		return derefSource(source, func(_ jen.Code) jen.Code {
+			return target.Expr().Op("=").Qual(pgtypePkgPath, "Interval").Values(jen.Dict{
+				jen.Id("Microseconds"): source.Expr().Dot("Microseconds").Call(),
+				jen.Id("Valid"):        jen.Lit(true),
+			})
+		})
+	})
+}
+
+func (c *intervalConverter) isDuration(t types.Type) bool {
+	return gen.TypeUtil.MatchNamedType(t, "time", "Duration")
+}
+
+var _ gen.Converter = (*intervalConverter)(nil)
@@ -21,6 +21,11 @@ func RegisterConverters() {
 	gen.RegisterConverter(Converter.Time)
 	gen.RegisterConverter(Converter.Timestamp)
 	gen.RegisterConverter(Converter.Timestamptz)
+	gen.RegisterConverter(Converter.UUID)
+	gen.RegisterConverter(Converter.UUIDString)
+	gen.RegisterConverter(Converter.Numeric)
+	gen.RegisterConverter(Converter.Interval)
+	gen.RegisterConverter(Converter.Range)
 }
 
 type converters struct {
@@ -37,6 +42,11 @@ type converters struct {
 	Time        gen.Converter
 	Timestamp   gen.Converter
 	Timestamptz gen.Converter
+	UUID        gen.Converter
+	UUIDString  gen.Converter
+	Numeric     gen.Converter
+	Interval    gen.Converter
+	Range       gen.Converter
 }
 
 var Converter = converters{
@@ -118,4 +128,9 @@ var Converter = converters{
 		ShortForm:            "pgtype.Timestamptz <-> [T *time.Time]",
 		ShortFormDescription: "pgtype.Timestamptz to T where T -> *time.Time is possible",
 	},
+	UUID:       &uuidBytesConverter{},
+	UUIDString: &uuidStringConverter{},
+	Numeric:    &numericConverter{},
+	Interval:   &intervalConverter{},
+	Range:      &rangeConverter{},
 }
@@ -0,0 +1,146 @@
+package pgtype
+
+import (
+	"go/types"
+	"log/slog"
+
+	"github.com/dave/jennifer/jen"
+	gen "github.com/toniphan21/go-mapper-gen"
+)
+
+// numericConverter converts pgtype.Numeric from/to float64, string (and their
+// pointers) or *big.Int. Values which cannot be represented by the other side, for
+// example NaN to *big.Int or a decimal to *big.Int, are left as zero value/NULL. A string
+// which is not a number is returned as error if the mapper function returns an error,
+// otherwise it becomes NULL.
+type numericConverter struct{}
+
+func (c *numericConverter) Init(_ gen.Parser, _ gen.Config, _ *slog.Logger) {
+	// no-op
+}
+
+func (c *numericConverter) Info() gen.ConverterInfo {
+	return gen.ConverterInfo{
+		Name:                 "built-in lib pgtype.Converter.Numeric",
+		ShortForm:            "pgtype.Numeric <-> [T float64|string|*big.Int]",
+		ShortFormDescription: "pgtype.Numeric to float64, *float64, string, *string or *big.Int",
+	}
+}
+
+func (c *numericConverter) CanConvert(ctx gen.LookupContext, targetType, sourceType types.Type) bool {
+	if isPgtype(sourceType, "Numeric") {
+		return c.isSupported(targetType)
+	}
+
+	if isPgtype(targetType, "Numeric") {
+		return c.isSupported(sourceType)
+	}
+	return false
+}
+
+func (c *numericConverter) ConvertField(ctx gen.ConverterContext, target, source gen.Symbol) jen.Code {
+	return ctx.Run(c, func() jen.Code {
+		if isPgtype(source.Type, "Numeric") {
+			return c.fromNumeric(ctx, target, source)
+		}
+		return c.toNumeric(ctx, target, source)
+	})
+}
+
+func (c *numericConverter) fromNumeric(ctx gen.ConverterContext, target, source gen.Symbol) jen.Code {
+	varName := ctx.NextVarName()
+
+	if c.isBigInt(target.Type) {
+		/** generated code:
+		if v0, err := {source}.Value(); err == nil && v0 != nil {
+			{target}, _ = new(big.Int).SetString(v0.(string), 10)
+		}
+		*/
+		return jen.If(
+			jen.List(jen.Id(varName), jen.Err()).Op(":=").Add(source.Expr()).Dot("Value").Call(),
+			jen.Err().Op("==").Nil().Op("&&").Id(varName).Op("!=").Nil(),
+		).Block(
+			jen.List(target.Expr(), jen.Id("_")).Op("=").New(jen.Qual("math/big", "Int")).
+				Dot("SetString").Call(jen.Id(varName).Assert(jen.String()), jen.Lit(10)),
+		)
+	}
+
+	if isString(elemType(target.Type)) {
+		/** generated code:
+		if v0, err := {source}.Value(); err == nil && v0 != nil {
+			{target} = v0.(string)
+		}
+		*/
+		return jen.If(
+			jen.List(jen.Id(varName), jen.Err()).Op(":=").Add(source.Expr()).Dot("Value").Call(),
+			jen.Err().Op("==").Nil().Op("&&").Id(varName).Op("!=").Nil(),
+		).BlockFunc(func(g *jen.Group) {
+			assignValue(ctx, g, target, jen.Id(varName).Assert(jen.String()))
+		})
+	}
+
+	/** generated code:
+	if v0, err := {source}.Float64Value(); err == nil && v0.Valid {
+		{target} = v0.Float64
+	}
+	*/
+	return jen.If(
+		jen.List(jen.Id(varName), jen.Err()).Op(":=").Add(source.Expr()).Dot("Float64Value").Call(),
+		jen.Err().Op("==").Nil().Op("&&").Id(varName).Dot("Valid"),
+	).BlockFunc(func(g *jen.Group) {
+		if _, ok := target.Type.(*types.Pointer); ok {
+			g.Add(target.Expr()).Op("=").Op("&").Id(varName).Dot("Float64")
+			return
+		}
+		g.Add(target.Expr()).Op("=").Id(varName).Dot("Float64")
+	})
+}
+
+func (c *numericConverter) toNumeric(ctx gen.ConverterContext, target, source gen.Symbol) jen.Code {
+	if c.isBigInt(source.Type) {
+		/** generated code:
+		if {source} != nil {
+			{target} = pgtype.Numeric{Int: new(big.Int).Set({source}), Valid: true}
+		}
+		*/
+		return jen.If(source.Expr().Op("!=").Nil()).Block(
+			target.Expr().Op("=").Qual(pgtypePkgPath, "Numeric").Values(jen.Dict{
+				jen.Id("Int"):   jen.New(jen.Qual("math/big", "Int")).Dot("Set").Call(source.Expr()),
+				jen.Id("Valid"): jen.Lit(true),
+			}),
+		)
+	}
+
+	if isString(elemType(source.Type)) {
+		/** generated code:
+		_ = {target}.Scan({source}) // see scanCode
+		*/
+		return derefSource(source, func(value jen.Code) jen.Code {
+			return scanCode(ctx, target, value)
+		})
+	}
+
+	/** generated code:
+	_ = {target}.Scan(strconv.FormatFloat({source}, 'f', -1, 64)) // see scanCode
+	*/
+	return derefSource(source, func(value jen.Code) jen.Code {
+		return scanCode(ctx, target,
+			jen.Qual("strconv", "FormatFloat").Call(value, jen.LitRune('f'), jen.Lit(-1), jen.Lit(64)),
+		)
+	})
+}
+
+func (c *numericConverter) isSupported(t types.Type) bool {
+	if c.isBigInt(t) {
+		return true
+	}
+
+	basic, ok := elemType(t).(*types.Basic)
+	return ok && (basic.Kind() == types.Float64 || basic.Kind() == types.String)
+}
+
+func (c *numericConverter) isBigInt(t types.Type) bool {
+	return gen.TypeUtil.IsPointerToNamedType(t, "math/big", "Int")
+}
+
+var _ gen.Converter = (*numericConverter)(nil)
@@ -0,0 +1,103 @@
+package pgtype
+
+import (
+	"go/types"
+	"log/slog"
+
+	"github.com/dave/jennifer/jen"
+	gen "github.com/toniphan21/go-mapper-gen"
+)
+
+// rangeConverter converts pgtype.Range[T] to pgtype.Range[V], the bounds are
+// converted by the converter found for T -> V, bound types and validity are copied.
+type rangeConverter struct{}
+
+func (c *rangeConverter) Init(_ gen.Parser, _ gen.Config, _ *slog.Logger) {
+	// no-op
+}
+
+func (c *rangeConverter) Info() gen.ConverterInfo {
+	return gen.ConverterInfo{
+		Name:                 "built-in lib pgtype.Converter.Range",
+		ShortForm:            "pgtype.Range[T] <-> pgtype.Range[V]",
+		ShortFormDescription: "range conversion; requires converter for T -> V",
+	}
+}
+
+func (c *rangeConverter) findTypeConverter(ctx gen.LookupContext, targetType, sourceType types.Type) (gen.Converter, types.Type, types.Type, bool) {
+	te, ok := c.elemType(targetType)
+	if !ok {
+		return nil, nil, nil, false
+	}
+
+	se, ok := c.elemType(sourceType)
+	if !ok {
+		return nil, nil, nil, false
+	}
+
+	other, _ := ctx.LookUp(c, te, se)
+	if other == nil {
+		return nil, nil, nil, false
+	}
+	return other, te, se, true
+}
+
+func (c *rangeConverter) CanConvert(ctx gen.LookupContext, targetType, sourceType types.Type) bool {
+	_, _, _, ok := c.findTypeConverter(ctx, targetType, sourceType)
+	return ok
+}
+
+func (c *rangeConverter) ConvertField(ctx gen.ConverterContext, target, source gen.Symbol) jen.Code {
+	return ctx.Run(c, func() jen.Code {
+		/** generated code:
+		{target} = pgtype.Range[V]{LowerType: {source}.LowerType, UpperType: {source}.UpperType, Valid: {source}.Valid}
+		// other converter converts {source}.Lower to {target}.Lower
+		// other converter converts {source}.Upper to {target}.Upper
+		*/
+		other, te, se, ok := c.findTypeConverter(ctx, target.Type, source.Type)
+		if !ok {
+			return nil
+		}
+
+		code := jen.Add(target.Expr()).Op("=").Add(gen.GeneratorUtil.TypeToJenCode(target.Type)).Values(jen.Dict{
+			jen.Id("LowerType"): source.Expr().Dot("LowerType"),
+			jen.Id("UpperType"): source.Expr().Dot("UpperType"),
+			jen.Id("Valid"):     source.Expr().Dot("Valid"),
+		}).Line()
+
+		for _, bound := range []string{"Lower", "Upper"} {
+			targetSymbol := c.boundSymbol(target, bound, te)
+			targetSymbol.Metadata = gen.SymbolMetadata{HasZeroValue: true}
+
+			convertedCode := other.ConvertField(ctx, targetSymbol, c.boundSymbol(source, bound, se))
+			if convertedCode == nil {
+				return nil
+			}
+			code = code.Add(convertedCode).Line()
+		}
+		return code
+	})
+}
+
+func (c *rangeConverter) elemType(t types.Type) (types.Type, bool) {
+	if !isPgtype(t, "Range") {
+		return nil, false
+	}
+
+	args := t.(*types.Named).TypeArgs()
+	if args.Len() != 1 {
+		return nil, false
+	}
+	return args.At(0), true
+}
+
+func (c *rangeConverter) boundSymbol(s gen.Symbol, bound string, t types.Type) gen.Symbol {
+	if s.FieldName == nil {
+		return gen.Symbol{VarName: s.VarName + "." + bound, Type: t}
+	}
+
+	fieldName := *s.FieldName + "." + bound
+	return gen.Symbol{VarName: s.VarName, FieldName: &fieldName, Type: t}
+}
+
+var _ gen.Converter = (*rangeConverter)(nil)
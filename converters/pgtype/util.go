@@ -0,0 +1,86 @@
+package pgtype
+
+import (
+	"go/types"
+
+	"github.com/dave/jennifer/jen"
+	gen "github.com/toniphan21/go-mapper-gen"
+)
+
+func isPgtype(t types.Type, typeName string) bool {
+	return gen.TypeUtil.MatchNamedType(t, pgtypePkgPath, typeName)
+}
+
+func isString(t types.Type) bool {
+	basic, ok := t.(*types.Basic)
+	return ok && basic.Kind() == types.String
+}
+
+// elemType returns T if t is *T, otherwise t itself.
+func elemType(t types.Type) types.Type {
+	if ptr, ok := t.(*types.Pointer); ok {
+		return ptr.Elem()
+	}
+	return t
+}
+
+// assignValue assigns value to target, takes the address of a temporary variable
+// when the target is a pointer.
+func assignValue(ctx gen.ConverterContext, g *jen.Group, target gen.Symbol, value jen.Code) {
+	/** generated code:
+	{target} = value
+
+	// or if target is a pointer
+	v0 := value
+	{target} = &v0
+	*/
+	if _, ok := target.Type.(*types.Pointer); !ok {
+		g.Add(target.Expr()).Op("=").Add(value)
+		return
+	}
+
+	varName := ctx.NextVarName()
+	g.Id(varName).Op(":=").Add(value)
+	g.Add(target.Expr()).Op("=").Op("&").Id(varName)
+}
+
+// scanCode calls Scan of target with value. The error is returned if the mapper function
+// returns an error, otherwise it is ignored and target is left invalid (NULL).
+func scanCode(ctx gen.ConverterContext, target gen.Symbol, value jen.Code) jen.Code {
+	/** generated code:
+	if err := {target}.Scan(value); err != nil {
+		return ..., err
+	}
+
+	// or if the mapper function does not return error
+	_ = {target}.Scan(value)
+	*/
+	scan := target.Expr().Dot("Scan").Call(value)
+	if !gen.GeneratorUtil.CanReturnError(ctx) {
+		return jen.Id("_").Op("=").Add(scan)
+	}
+
+	return jen.If(jen.Err().Op(":=").Add(scan), jen.Err().Op("!=").Nil()).Block(
+		gen.GeneratorUtil.ReturnError(ctx, jen.Err()),
+	)
+}
+
+// derefSource calls fn with the source expression, a nil check is added and the
+// source is dereferenced when the source is a pointer.
+func derefSource(source gen.Symbol, fn func(value jen.Code) jen.Code) jen.Code {
+	/** generated code:
+	fn({source})
+
+	// or if source is a pointer
+	if {source} != nil {
+		fn(*{source})
+	}
+	*/
+	if _, ok := source.Type.(*types.Pointer); !ok {
+		return fn(source.Expr())
+	}
+
+	return jen.If(source.Expr().Op("!=").Nil()).Block(
+		fn(jen.Op("*").Add(source.Expr())),
+	)
+}
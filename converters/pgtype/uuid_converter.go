@@ -0,0 +1,141 @@
+package pgtype
+
+import (
+	"go/types"
+	"log/slog"
+
+	"github.com/dave/jennifer/jen"
+	gen "github.com/toniphan21/go-mapper-gen"
+)
+
+// --- pgtype.UUID <-> [16]byte
+
+// uuidBytesConverter converts pgtype.UUID from/to [16]byte or any named type whose
+// underlying type is [16]byte, such as github.com/google/uuid.UUID or github.com/gofrs/uuid.UUID.
+type uuidBytesConverter struct{}
+
+func (c *uuidBytesConverter) Init(_ gen.Parser, _ gen.Config, _ *slog.Logger) {
+	// no-op
+}
+
+func (c *uuidBytesConverter) Info() gen.ConverterInfo {
+	return gen.ConverterInfo{
+		Name:                 "built-in lib pgtype.Converter.UUID",
+		ShortForm:            "pgtype.UUID <-> [T [16]byte]",
+		ShortFormDescription: "pgtype.UUID to T or *T where T is [16]byte or a named type of [16]byte like uuid.UUID",
+	}
+}
+
+func (c *uuidBytesConverter) CanConvert(ctx gen.LookupContext, targetType, sourceType types.Type) bool {
+	if isPgtype(sourceType, "UUID") {
+		return c.isBytes(elemType(targetType))
+	}
+
+	if isPgtype(targetType, "UUID") {
+		return c.isBytes(elemType(sourceType))
+	}
+	return false
+}
+
+func (c *uuidBytesConverter) ConvertField(ctx gen.ConverterContext, target, source gen.Symbol) jen.Code {
+	return ctx.Run(c, func() jen.Code {
+		if isPgtype(source.Type, "UUID") {
+			/** generated code:
+			if {source}.Valid {
+				{target} = T({source}.Bytes)
+			}
+			*/
+			value := source.Expr().Dot("Bytes")
+			if _, ok := elemType(target.Type).(*types.Named); ok {
+				value = jen.Add(gen.GeneratorUtil.TypeToJenCode(elemType(target.Type))).Call(value)
+			}
+			return jen.If(source.Expr().Dot("Valid")).BlockFunc(func(g *jen.Group) {
+				assignValue(ctx, g, target, value)
+			})
+		}
+
+		/** generated code:
+		{target} = pgtype.UUID{Bytes: [16]byte({source}), Valid: true}
+		*/
+		return derefSource(source, func(value jen.Code) jen.Code {
+			if _, ok := elemType(source.Type).(*types.Named); ok {
+				value = jen.Index(jen.Lit(16)).Byte().Call(value)
+			}
+
+			return target.Expr().Op("=").Qual(pgtypePkgPath, "UUID").Values(jen.Dict{
+				jen.Id("Bytes"): value,
+				jen.Id("Valid"): jen.Lit(true),
+			})
+		})
+	})
+}
+
+func (c *uuidBytesConverter) isBytes(t types.Type) bool {
+	if isPgtype(t, "UUID") {
+		return false
+	}
+
+	arr, ok := t.Underlying().(*types.Array)
+	if !ok || arr.Len() != 16 {
+		return false
+	}
+
+	basic, ok := arr.Elem().(*types.Basic)
+	return ok && basic.Kind() == types.Byte
+}
+
+var _ gen.Converter = (*uuidBytesConverter)(nil)
+
+// --- pgtype.UUID <-> string
+
+// uuidStringConverter converts pgtype.UUID from/to string or *string. A string which is
+// not a valid UUID is returned as error if the mapper function returns an error, otherwise
+// it is converted to an invalid (NULL) pgtype.UUID.
+type uuidStringConverter struct{}
+
+func (c *uuidStringConverter) Init(_ gen.Parser, _ gen.Config, _ *slog.Logger) {
+	// no-op
+}
+
+func (c *uuidStringConverter) Info() gen.ConverterInfo {
+	return gen.ConverterInfo{
+		Name:                 "built-in lib pgtype.Converter.UUIDString",
+		ShortForm:            "pgtype.UUID <-> [T string]",
+		ShortFormDescription: "pgtype.UUID to string or *string, invalid string becomes NULL",
+	}
+}
+
+func (c *uuidStringConverter) CanConvert(ctx gen.LookupContext, targetType, sourceType types.Type) bool {
+	if isPgtype(sourceType, "UUID") {
+		return isString(elemType(targetType))
+	}
+
+	if isPgtype(targetType, "UUID") {
+		return isString(elemType(sourceType))
+	}
+	return false
+}
+
+func (c *uuidStringConverter) ConvertField(ctx gen.ConverterContext, target, source gen.Symbol) jen.Code {
+	return ctx.Run(c, func() jen.Code {
+		if isPgtype(source.Type, "UUID") {
+			/** generated code:
+			if {source}.Valid {
+				{target} = {source}.String()
+			}
+			*/
+			return jen.If(source.Expr().Dot("Valid")).BlockFunc(func(g *jen.Group) {
+				assignValue(ctx, g, target, source.Expr().Dot("String").Call())
+			})
+		}
+
+		/** generated code:
+		_ = {target}.Scan({source}) // see scanCode
+		*/
+		return derefSource(source, func(value jen.Code) jen.Code {
+			return scanCode(ctx, target, value)
+		})
+	})
+}
+
+var _ gen.Converter = (*uuidStringConverter)(nil)
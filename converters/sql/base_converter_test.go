@@ -2,6 +2,7 @@ package sql
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	gen "github.com/toniphan21/go-mapper-gen"
@@ -303,3 +304,120 @@ func Test_baseConverter_convertible(t *testing.T) {
 		})
 	}
 }
+
+func Test_baseConverter_nullMode(t *testing.T) {
+	cases := []struct {
+		mode     gen.NullMode
+		target   string
+		source   string
+		expected []string
+	}{
+		{
+			mode: gen.NullModeZeroOnNull, source: "sql.NullString", target: "string",
+			expected: []string{
+				`if in.sourceField.Valid {`,
+				`	out.targetField = in.sourceField.String`,
+				`} else {`,
+				`	var zero string`,
+				`	out.targetField = zero`,
+				`}`,
+			},
+		},
+		{
+			mode: gen.NullModeZeroOnNull, source: "string", target: "sql.NullString",
+			expected: []string{
+				`out.targetField = sql.NullString{`,
+				`	String: in.sourceField,`,
+				`	Valid:  true,`,
+				`}`,
+			},
+		},
+		{
+			mode: gen.NullModeValidIfNonZero, source: "sql.NullInt64", target: "int64",
+			expected: []string{
+				`if in.sourceField.Valid {`,
+				`	out.targetField = in.sourceField.Int64`,
+				`} else {`,
+				`	var zero int64`,
+				`	out.targetField = zero`,
+				`}`,
+			},
+		},
+		{
+			mode: gen.NullModeValidIfNonZero, source: "int64", target: "sql.NullInt64",
+			expected: []string{
+				``,
+				`var v0 int64`,
+				`if in.sourceField != v0 {`,
+				`	out.targetField = sql.NullInt64{`,
+				`		Int64: in.sourceField,`,
+				`		Valid: true,`,
+				`	}`,
+				`} else {`,
+				`	var zero sql.NullInt64`,
+				`	out.targetField = zero`,
+				`}`,
+			},
+		},
+		{
+			mode: gen.NullModeAlwaysValid, source: "sql.NullBool", target: "bool",
+			expected: []string{
+				`out.targetField = in.sourceField.Bool`,
+			},
+		},
+		{
+			mode: gen.NullModeAlwaysValid, source: "bool", target: "sql.NullBool",
+			expected: []string{
+				`out.targetField = sql.NullBool{`,
+				`	Bool:  in.sourceField,`,
+				`	Valid: true,`,
+				`}`,
+			},
+		},
+		{
+			mode: gen.NullModeAlwaysValid, source: "sql.NullBool", target: "*bool",
+			expected: []string{
+				`if in.sourceField.Valid {`,
+				`	out.targetField = &in.sourceField.Bool`,
+				`}`,
+			},
+		},
+	}
+
+	instances := map[string]gen.Converter{
+		"sql.NullString": Converter.NullString,
+		"sql.NullInt64":  Converter.NullInt64,
+		"sql.NullBool":   Converter.NullBool,
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.source+" -> "+tc.target, func(t *testing.T) {
+			instance, ok := instances[tc.source]
+			if !ok {
+				instance = instances[tc.target]
+			}
+
+			ctc := gen.ConverterTestCase{
+				Name:               t.Name(),
+				TargetType:         tc.target,
+				SourceType:         tc.source,
+				Imports:            map[string]string{"sql": "database/sql"},
+				Config:             &gen.Config{LibraryConverters: gen.LibraryConverterConfig{NullMode: tc.mode}},
+				ExpectedCanConvert: true,
+				ExpectedCode:       tc.expected,
+			}
+
+			if strings.HasPrefix(tc.target, "sql.") {
+				ctc.ExpectedImports = []string{`import "database/sql"`}
+			}
+
+			gen.ClearAllRegisteredConverters()
+			gen.RegisterConverter(gen.BuiltinConverters.IdenticalType)
+			gen.RegisterConverter(gen.BuiltinConverters.PointerToType)
+			gen.RegisterConverter(gen.BuiltinConverters.TypeToPointer)
+			gen.RegisterConverter(instance)
+
+			gen.Test.RunConverterTestCase(t, ctc, instance)
+		})
+	}
+}
@@ -19,6 +19,7 @@ func TestGolden(t *testing.T) {
 		printDiff   bool
 	}{
 		{file: "testdata/null_bool.md"},
+		{file: "testdata/null_generic.md"},
 	}
 
 	for _, tc := range cases {
@@ -16,6 +16,7 @@ func RegisterConverters() {
 	gen.RegisterConverter(Converter.NullInt64)
 	gen.RegisterConverter(Converter.NullString)
 	gen.RegisterConverter(Converter.NullTime)
+	gen.RegisterConverter(Converter.Null)
 }
 
 type converters struct {
@@ -27,6 +28,7 @@ type converters struct {
 	NullInt64   gen.Converter
 	NullString  gen.Converter
 	NullTime    gen.Converter
+	Null        gen.Converter
 }
 
 var Converter = converters{
@@ -78,4 +80,5 @@ var Converter = converters{
 		ShortForm:            "sql.NullTime <-> [T *time.Time]",
 		ShortFormDescription: "sql.NullTime to T where T -> *time.Time is possible",
 	},
+	Null: &nullConverter{},
 }
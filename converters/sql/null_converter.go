@@ -0,0 +1,178 @@
+package sql
+
+import (
+	"go/types"
+	"log/slog"
+
+	"github.com/dave/jennifer/jen"
+	gen "github.com/toniphan21/go-mapper-gen"
+)
+
+// nullConverter converts any instantiation of the generic sql.Null[T] from/to *V,
+// the value is converted by the converter found for T -> V (or V -> T) when T and V
+// are not identical.
+type nullConverter struct{}
+
+func (c *nullConverter) Init(_ gen.Parser, _ gen.Config, _ *slog.Logger) {
+	// no-op
+}
+
+func (c *nullConverter) Info() gen.ConverterInfo {
+	return gen.ConverterInfo{
+		Name:                 "built-in lib sql.Converter.Null",
+		ShortForm:            "sql.Null[T] <-> [*V]",
+		ShortFormDescription: "sql.Null[T] to *V; requires converter for T -> V if T and V are not identical",
+	}
+}
+
+// findTypeConverter returns the converter for the value type of sql.Null[T] and the
+// pointer's element type, the converter is nil if both types are identical.
+func (c *nullConverter) findTypeConverter(ctx gen.LookupContext, targetType, sourceType types.Type) (gen.Converter, types.Type, types.Type, bool) {
+	var te, se types.Type
+	if st, ok := c.valueType(sourceType); ok {
+		ptr, ok := targetType.(*types.Pointer)
+		if !ok {
+			return nil, nil, nil, false
+		}
+		te, se = ptr.Elem(), st
+	} else if tt, ok := c.valueType(targetType); ok {
+		ptr, ok := sourceType.(*types.Pointer)
+		if !ok {
+			return nil, nil, nil, false
+		}
+		te, se = tt, ptr.Elem()
+	} else {
+		return nil, nil, nil, false
+	}
+
+	if gen.TypeUtil.IsIdentical(te, se) {
+		return nil, te, se, true
+	}
+
+	other, _ := ctx.LookUp(c, te, se)
+	if other == nil {
+		return nil, nil, nil, false
+	}
+	return other, te, se, true
+}
+
+func (c *nullConverter) CanConvert(ctx gen.LookupContext, targetType, sourceType types.Type) bool {
+	_, _, _, ok := c.findTypeConverter(ctx, targetType, sourceType)
+	return ok
+}
+
+func (c *nullConverter) ConvertField(ctx gen.ConverterContext, target, source gen.Symbol) jen.Code {
+	return ctx.Run(c, func() jen.Code {
+		other, te, se, ok := c.findTypeConverter(ctx, target.Type, source.Type)
+		if !ok {
+			return nil
+		}
+
+		if _, ok := c.valueType(source.Type); ok {
+			return c.fromNull(ctx, target, source, other, te)
+		}
+		return c.toNull(ctx, target, source, other, te, se)
+	})
+}
+
+func (c *nullConverter) fromNull(ctx gen.ConverterContext, target, source gen.Symbol, oc gen.Converter, te types.Type) jen.Code {
+	/** generated code:
+	if {source}.Valid {
+		{target} = &{source}.V
+	}
+
+	// or if T and V are not identical
+	if {source}.Valid {
+		var v0 V
+		// other converter converts {source}.V to v0
+		{target} = &v0
+	}
+	*/
+	if oc == nil {
+		return jen.If(source.Expr().Dot("Valid")).Block(
+			target.Expr().Op("=").Op("&").Add(source.Expr().Dot("V")),
+		)
+	}
+
+	varName := ctx.NextVarName()
+	targetSymbol := gen.Symbol{VarName: varName, Type: te, Metadata: gen.SymbolMetadata{IsVariable: true, HasZeroValue: true}}
+	convertedCode := oc.ConvertField(ctx, targetSymbol, c.valueSymbol(source))
+	if convertedCode == nil {
+		return nil
+	}
+
+	return jen.If(source.Expr().Dot("Valid")).BlockFunc(func(g *jen.Group) {
+		g.Var().Id(varName).Add(gen.GeneratorUtil.TypeToJenCode(te))
+		g.Add(convertedCode)
+		g.Add(target.Expr()).Op("=").Op("&").Id(varName)
+	})
+}
+
+func (c *nullConverter) toNull(ctx gen.ConverterContext, target, source gen.Symbol, oc gen.Converter, te, se types.Type) jen.Code {
+	/** generated code:
+	if {source} != nil {
+		{target} = sql.Null[T]{V: *{source}, Valid: true}
+	}
+
+	// or if T and V are not identical
+	if {source} != nil {
+		v0 := *{source}
+		var v1 T
+		// other converter converts v0 to v1
+		{target} = sql.Null[T]{V: v1, Valid: true}
+	}
+	*/
+	value := func(v jen.Code) jen.Code {
+		return jen.Add(gen.GeneratorUtil.TypeToJenCode(target.Type)).Values(jen.Dict{
+			jen.Id("V"):     v,
+			jen.Id("Valid"): jen.Lit(true),
+		})
+	}
+
+	if oc == nil {
+		return jen.If(source.Expr().Op("!=").Nil()).Block(
+			target.Expr().Op("=").Add(value(jen.Op("*").Add(source.Expr()))),
+		)
+	}
+
+	sourceVarName := ctx.NextVarName()
+	targetVarName := ctx.NextVarName()
+	sourceSymbol := gen.Symbol{VarName: sourceVarName, Type: se}
+	targetSymbol := gen.Symbol{VarName: targetVarName, Type: te, Metadata: gen.SymbolMetadata{IsVariable: true, HasZeroValue: true}}
+	convertedCode := oc.ConvertField(ctx, targetSymbol, sourceSymbol)
+	if convertedCode == nil {
+		return nil
+	}
+
+	return jen.If(source.Expr().Op("!=").Nil()).BlockFunc(func(g *jen.Group) {
+		g.Id(sourceVarName).Op(":=").Op("*").Add(source.Expr())
+		g.Var().Id(targetVarName).Add(gen.GeneratorUtil.TypeToJenCode(te))
+		g.Add(convertedCode)
+		g.Add(target.Expr()).Op("=").Add(value(jen.Id(targetVarName)))
+	})
+}
+
+// valueType returns T if t is an instantiation of sql.Null[T].
+func (c *nullConverter) valueType(t types.Type) (types.Type, bool) {
+	if !gen.TypeUtil.MatchNamedType(t, sqlPkgPath, "Null") {
+		return nil, false
+	}
+
+	args := t.(*types.Named).TypeArgs()
+	if args.Len() != 1 {
+		return nil, false
+	}
+	return args.At(0), true
+}
+
+func (c *nullConverter) valueSymbol(s gen.Symbol) gen.Symbol {
+	t, _ := c.valueType(s.Type)
+	if s.FieldName == nil {
+		return gen.Symbol{VarName: s.VarName + ".V", Type: t}
+	}
+
+	fieldName := *s.FieldName + ".V"
+	return gen.Symbol{VarName: s.VarName, FieldName: &fieldName, Type: t}
+}
+
+var _ gen.Converter = (*nullConverter)(nil)
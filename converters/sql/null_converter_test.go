@@ -0,0 +1,87 @@
+package sql
+
+import (
+	"strings"
+	"testing"
+
+	gen "github.com/toniphan21/go-mapper-gen"
+)
+
+func Test_nullConverter(t *testing.T) {
+	cases := []struct {
+		target     string
+		source     string
+		canConvert bool
+		expected   []string
+	}{
+		{
+			source: "sql.Null[string]", target: "*string", canConvert: true,
+			expected: []string{
+				`if in.sourceField.Valid {`,
+				`	out.targetField = &in.sourceField.V`,
+				`}`,
+			},
+		},
+		{
+			source: "*string", target: "sql.Null[string]", canConvert: true,
+			expected: []string{
+				`if in.sourceField != nil {`,
+				`	out.targetField = sql.Null[string]{`,
+				`		V:     *in.sourceField,`,
+				`		Valid: true,`,
+				`	}`,
+				`}`,
+			},
+		},
+		{
+			source: "sql.Null[int32]", target: "*int64", canConvert: true,
+			expected: []string{
+				`if in.sourceField.Valid {`,
+				`	var v0 int64`,
+				`	v0 = int64(in.sourceField.V)`,
+				`	out.targetField = &v0`,
+				`}`,
+			},
+		},
+		{
+			source: "*int64", target: "sql.Null[int32]", canConvert: true,
+			expected: []string{
+				`if in.sourceField != nil {`,
+				`	v0 := *in.sourceField`,
+				`	var v1 int32`,
+				`	v1 = int32(v0)`,
+				`	out.targetField = sql.Null[int32]{`,
+				`		V:     v1,`,
+				`		Valid: true,`,
+				`	}`,
+				`}`,
+			},
+		},
+		{source: "sql.Null[string]", target: "*int64", canConvert: false},
+		{source: "sql.Null[string]", target: "string", canConvert: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.source+" -> "+tc.target, func(t *testing.T) {
+			ctc := gen.ConverterTestCase{
+				Name:               t.Name(),
+				TargetType:         tc.target,
+				SourceType:         tc.source,
+				Imports:            map[string]string{"sql": "database/sql"},
+				ExpectedCanConvert: tc.canConvert,
+				ExpectedCode:       tc.expected,
+			}
+
+			if tc.canConvert && strings.HasPrefix(tc.target, "sql.") {
+				ctc.ExpectedImports = []string{`import "database/sql"`}
+			}
+
+			gen.ClearAllRegisteredConverters()
+			gen.RegisterConverter(gen.BuiltinConverters.IdenticalType)
+			gen.RegisterConverter(Converter.Null)
+			gen.RegisterConverter(gen.BuiltinConverters.Numeric)
+
+			gen.Test.RunConverterTestCase(t, ctc, Converter.Null)
+		})
+	}
+}
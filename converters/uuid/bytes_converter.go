@@ -0,0 +1,66 @@
+package uuid
+
+import (
+	"go/types"
+	"log/slog"
+
+	"github.com/dave/jennifer/jen"
+	gen "github.com/toniphan21/go-mapper-gen"
+)
+
+// bytesConverter converts uuid.UUID from/to [16]byte or any other named type whose
+// underlying type is [16]byte, such as the UUID type of the other uuid package.
+type bytesConverter struct {
+	lib  library
+	Name string
+}
+
+func (c *bytesConverter) Init(_ gen.Parser, _ gen.Config, _ *slog.Logger) {
+	// no-op
+}
+
+func (c *bytesConverter) Info() gen.ConverterInfo {
+	return gen.ConverterInfo{
+		Name:                 c.Name,
+		ShortForm:            "uuid.UUID <-> [T [16]byte]",
+		ShortFormDescription: "uuid.UUID to T where T is [16]byte or a named type of [16]byte",
+	}
+}
+
+func (c *bytesConverter) CanConvert(ctx gen.LookupContext, targetType, sourceType types.Type) bool {
+	if c.lib.isType(sourceType, "UUID") {
+		return c.isBytes(targetType)
+	}
+
+	if c.lib.isType(targetType, "UUID") {
+		return c.isBytes(sourceType)
+	}
+	return false
+}
+
+func (c *bytesConverter) ConvertField(ctx gen.ConverterContext, target, source gen.Symbol) jen.Code {
+	return ctx.Run(c, func() jen.Code {
+		c.lib.importName(ctx)
+
+		/** generated code:
+		{target} = T({source})
+		*/
+		return target.Expr().Op("=").Add(gen.GeneratorUtil.TypeToJenCode(target.Type)).Call(source.Expr())
+	})
+}
+
+func (c *bytesConverter) isBytes(t types.Type) bool {
+	if c.lib.isType(t, "UUID") {
+		return false
+	}
+
+	arr, ok := t.Underlying().(*types.Array)
+	if !ok || arr.Len() != 16 {
+		return false
+	}
+
+	basic, ok := arr.Elem().(*types.Basic)
+	return ok && basic.Kind() == types.Byte
+}
+
+var _ gen.Converter = (*bytesConverter)(nil)
@@ -0,0 +1,82 @@
+package uuid
+
+import (
+	"go/types"
+
+	"github.com/dave/jennifer/jen"
+	gen "github.com/toniphan21/go-mapper-gen"
+)
+
+func RegisterConverters() {
+	gen.RegisterConverter(Converter.UUIDString)
+	gen.RegisterConverter(Converter.UUIDBytes)
+	gen.RegisterConverter(Converter.NullUUID)
+	gen.RegisterConverter(Converter.GofrsUUIDString)
+	gen.RegisterConverter(Converter.GofrsUUIDBytes)
+	gen.RegisterConverter(Converter.GofrsNullUUID)
+}
+
+type converters struct {
+	UUIDString      gen.Converter
+	UUIDBytes       gen.Converter
+	NullUUID        gen.Converter
+	GofrsUUIDString gen.Converter
+	GofrsUUIDBytes  gen.Converter
+	GofrsNullUUID   gen.Converter
+}
+
+var Converter = converters{
+	UUIDString: &stringConverter{
+		lib:  google,
+		Name: "built-in lib uuid.Converter.UUIDString",
+	},
+	UUIDBytes: &bytesConverter{
+		lib:  google,
+		Name: "built-in lib uuid.Converter.UUIDBytes",
+	},
+	NullUUID: &nullConverter{
+		lib:  google,
+		Name: "built-in lib uuid.Converter.NullUUID",
+	},
+	GofrsUUIDString: &stringConverter{
+		lib:  gofrs,
+		Name: "built-in lib uuid.Converter.GofrsUUIDString",
+	},
+	GofrsUUIDBytes: &bytesConverter{
+		lib:  gofrs,
+		Name: "built-in lib uuid.Converter.GofrsUUIDBytes",
+	},
+	GofrsNullUUID: &nullConverter{
+		lib:  gofrs,
+		Name: "built-in lib uuid.Converter.GofrsNullUUID",
+	},
+}
+
+// library describes a uuid package, both packages share the same API shape: UUID is
+// a [16]byte, NullUUID has UUID and Valid fields, and Must wraps a parse function.
+type library struct {
+	pkgPath   string
+	parseFunc string
+}
+
+var google = library{pkgPath: "github.com/google/uuid", parseFunc: "Parse"}
+
+var gofrs = library{pkgPath: "github.com/gofrs/uuid/v5", parseFunc: "FromString"}
+
+func (l library) typeInfo(typeName string, isPointer bool) gen.TypeInfo {
+	return gen.TypeInfo{PkgPath: l.pkgPath, PkgName: "uuid", TypeName: typeName, IsPointer: isPointer}
+}
+
+func (l library) isType(t types.Type, typeName string) bool {
+	return gen.TypeUtil.MatchNamedType(t, l.pkgPath, typeName)
+}
+
+// importName makes the generated file import the package as uuid, otherwise jennifer
+// guesses the name from the last path element, which is "v5" for gofrs.
+func (l library) importName(ctx gen.ConverterContext) {
+	ctx.JenFile().ImportName(l.pkgPath, "uuid")
+}
+
+func (l library) qual(name string) *jen.Statement {
+	return jen.Qual(l.pkgPath, name)
+}
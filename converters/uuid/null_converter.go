@@ -0,0 +1,128 @@
+package uuid
+
+import (
+	"go/types"
+	"log/slog"
+
+	"github.com/dave/jennifer/jen"
+	gen "github.com/toniphan21/go-mapper-gen"
+)
+
+// nullConverter converts uuid.NullUUID from/to *uuid.UUID.
+type nullConverter struct {
+	lib  library
+	Name string
+
+	orchestrator gen.GeneratedTypeOrchestrator
+	targetedType types.Type
+}
+
+func (c *nullConverter) Init(_ gen.Parser, _ gen.Config, _ *slog.Logger) {
+	target := c.lib.typeInfo("UUID", true)
+	c.targetedType = target.ToType()
+	c.orchestrator = gen.GeneratedTypeOrchestrator{
+		Generated:                c.lib.typeInfo("NullUUID", false),
+		Target:                   target,
+		GeneratedToTarget:        c.nullUUIDToTarget,
+		GeneratedToTargetToOther: c.nullUUIDToTargetToOther,
+		TargetToGenerated:        c.targetToNullUUID,
+		OtherToTargetToGenerated: c.otherToTargetToNullUUID,
+	}
+}
+
+func (c *nullConverter) Info() gen.ConverterInfo {
+	return gen.ConverterInfo{
+		Name:                 c.Name,
+		ShortForm:            "uuid.NullUUID <-> [T *uuid.UUID]",
+		ShortFormDescription: "uuid.NullUUID to T where T -> *uuid.UUID is possible",
+	}
+}
+
+func (c *nullConverter) CanConvert(ctx gen.LookupContext, targetType, sourceType types.Type) bool {
+	return c.orchestrator.CanConvert(c, ctx, targetType, sourceType)
+}
+
+func (c *nullConverter) ConvertField(ctx gen.ConverterContext, target, source gen.Symbol) jen.Code {
+	return ctx.Run(c, func() jen.Code {
+		c.lib.importName(ctx)
+
+		return c.orchestrator.PerformConvert(c, ctx, target, source)
+	})
+}
+
+func (c *nullConverter) nullUUIDToTarget(ctx gen.ConverterContext, target, source gen.Symbol) jen.Code {
+	/** generated code:
+	if {source}.Valid {
+		{target} = &{source}.UUID
+	}
+	*/
+	return jen.If(source.Expr().Dot("Valid")).Block(
+		target.Expr().Op("=").Op("&").Add(source.Expr().Dot("UUID")),
+	)
+}
+
+func (c *nullConverter) nullUUIDToTargetToOther(ctx gen.ConverterContext, target, source gen.Symbol, oc gen.Converter) jen.Code {
+	/** generated code:
+	var v0 *uuid.UUID
+	if {source}.Valid {
+		v0 = &{source}.UUID
+	}
+
+	// other converter converts v0 to target
+	*/
+	varName := ctx.NextVarName()
+	code := jen.Line().Var().Id(varName).Add(gen.GeneratorUtil.TypeToJenCode(c.targetedType)).Line()
+	code = code.If(source.Expr().Dot("Valid")).Block(
+		jen.Id(varName).Op("=").Op("&").Add(source.Expr().Dot("UUID")),
+	).Line()
+
+	sourceSymbol := gen.Symbol{VarName: varName, Type: c.targetedType}
+	convertedCode := oc.ConvertField(ctx, target, sourceSymbol)
+	if convertedCode == nil {
+		return nil
+	}
+	return code.Add(convertedCode).Line()
+}
+
+func (c *nullConverter) targetToNullUUID(ctx gen.ConverterContext, target, source gen.Symbol) jen.Code {
+	/** generated code:
+	if {source} != nil {
+		{target} = uuid.NullUUID{UUID: *{source}, Valid: true}
+	}
+	*/
+	return jen.If(source.Expr().Op("!=").Nil()).Block(
+		target.Expr().Op("=").Add(c.nullUUID(jen.Op("*").Add(source.Expr()))),
+	)
+}
+
+func (c *nullConverter) otherToTargetToNullUUID(ctx gen.ConverterContext, target, source gen.Symbol, oc gen.Converter) jen.Code {
+	/** generated code:
+	var v0 *uuid.UUID
+	// other converter converts source to v0
+
+	if v0 != nil {
+		{target} = uuid.NullUUID{UUID: *v0, Valid: true}
+	}
+	*/
+	varName := ctx.NextVarName()
+	code := jen.Line().Var().Id(varName).Add(gen.GeneratorUtil.TypeToJenCode(c.targetedType)).Line()
+	targetSymbol := gen.Symbol{VarName: varName, Type: c.targetedType, Metadata: gen.SymbolMetadata{IsVariable: true, HasZeroValue: true}}
+	convertedCode := oc.ConvertField(ctx, targetSymbol, source)
+	if convertedCode == nil {
+		return nil
+	}
+	code.Add(convertedCode).Line()
+
+	return code.If(jen.Id(varName).Op("!=").Nil()).Block(
+		target.Expr().Op("=").Add(c.nullUUID(jen.Op("*").Id(varName))),
+	)
+}
+
+func (c *nullConverter) nullUUID(value jen.Code) jen.Code {
+	return c.lib.qual("NullUUID").Values(jen.Dict{
+		jen.Id("UUID"):  value,
+		jen.Id("Valid"): jen.Lit(true),
+	})
+}
+
+var _ gen.Converter = (*nullConverter)(nil)
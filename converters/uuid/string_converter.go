@@ -0,0 +1,103 @@
+package uuid
+
+import (
+	"go/types"
+	"log/slog"
+
+	"github.com/dave/jennifer/jen"
+	gen "github.com/toniphan21/go-mapper-gen"
+)
+
+// stringConverter converts uuid.UUID from/to string. Parsing a string either returns
+// the error from the mapper or panics via uuid.Must, depending on gen.ParseMode.
+type stringConverter struct {
+	lib  library
+	Name string
+
+	parseMode    gen.ParseMode
+	orchestrator gen.GeneratedTypeOrchestrator
+}
+
+func (c *stringConverter) Init(_ gen.Parser, config gen.Config, _ *slog.Logger) {
+	c.parseMode = config.LibraryConverters.UUIDParse
+	c.orchestrator = gen.GeneratedTypeOrchestrator{
+		Generated:                c.lib.typeInfo("UUID", false),
+		Target:                   gen.MakeTypeInfo(""),
+		GeneratedToTarget:        c.uuidToString,
+		GeneratedToTargetToOther: c.uuidToStringToOther,
+		TargetToGenerated:        c.stringToUUID,
+	}
+}
+
+func (c *stringConverter) Info() gen.ConverterInfo {
+	return gen.ConverterInfo{
+		Name:                 c.Name,
+		ShortForm:            "uuid.UUID <-> [T string]",
+		ShortFormDescription: "uuid.UUID to T where string -> T is possible, string to uuid.UUID",
+	}
+}
+
+func (c *stringConverter) CanConvert(ctx gen.LookupContext, targetType, sourceType types.Type) bool {
+	if c.lib.isType(targetType, "UUID") && c.parseMode == gen.ParseModeError && !gen.GeneratorUtil.CanReturnError(ctx) {
+		return false
+	}
+	return c.orchestrator.CanConvert(c, ctx, targetType, sourceType)
+}
+
+func (c *stringConverter) ConvertField(ctx gen.ConverterContext, target, source gen.Symbol) jen.Code {
+	return ctx.Run(c, func() jen.Code {
+		c.lib.importName(ctx)
+
+		return c.orchestrator.PerformConvert(c, ctx, target, source)
+	})
+}
+
+func (c *stringConverter) uuidToString(ctx gen.ConverterContext, target, source gen.Symbol) jen.Code {
+	/** generated code:
+	{target} = {source}.String()
+	*/
+	return target.Expr().Op("=").Add(source.Expr()).Dot("String").Call()
+}
+
+func (c *stringConverter) uuidToStringToOther(ctx gen.ConverterContext, target, source gen.Symbol, oc gen.Converter) jen.Code {
+	/** generated code:
+	var v0 string
+	v0 = {source}.String()
+
+	// other converter converts v0 to target
+	*/
+	varName := ctx.NextVarName()
+	code := jen.Line().Var().Id(varName).String().Line()
+	code = code.Id(varName).Op("=").Add(source.Expr()).Dot("String").Call().Line()
+
+	sourceSymbol := gen.Symbol{VarName: varName, Type: types.Typ[types.String]}
+	convertedCode := oc.ConvertField(ctx, target, sourceSymbol)
+	if convertedCode == nil {
+		return nil
+	}
+	return code.Add(convertedCode).Line()
+}
+
+func (c *stringConverter) stringToUUID(ctx gen.ConverterContext, target, source gen.Symbol) jen.Code {
+	/** generated code:
+	{target} = uuid.Must(uuid.Parse({source}))
+
+	// or if parse mode is error
+	v0, err := uuid.Parse({source})
+	if err != nil {
+		return ..., err
+	}
+	{target} = v0
+	*/
+	parse := c.lib.qual(c.lib.parseFunc).Call(source.Expr())
+	if c.parseMode == gen.ParseModeMust {
+		return target.Expr().Op("=").Add(c.lib.qual("Must")).Call(parse)
+	}
+
+	varName := ctx.NextVarName()
+	code := jen.List(jen.Id(varName), jen.Err()).Op(":=").Add(parse).Line()
+	code = code.If(jen.Err().Op("!=").Nil()).Block(gen.GeneratorUtil.ReturnError(ctx, jen.Err())).Line()
+	return code.Add(target.Expr()).Op("=").Id(varName)
+}
+
+var _ gen.Converter = (*stringConverter)(nil)
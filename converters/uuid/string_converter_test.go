@@ -0,0 +1,73 @@
+package uuid
+
+import (
+	"testing"
+
+	gen "github.com/toniphan21/go-mapper-gen"
+)
+
+func Test_stringConverter(t *testing.T) {
+	cases := []struct {
+		name        string
+		parseMode   gen.ParseMode
+		returnError bool
+		target      string
+		source      string
+		canConvert  bool
+		expected    []string
+	}{
+		{
+			name: "uuid to string", target: "string", source: "uuid.UUID", canConvert: true,
+			expected: []string{
+				`out.targetField = in.sourceField.String()`,
+			},
+		},
+		{
+			name: "string to uuid, parse mode error", parseMode: gen.ParseModeError, returnError: true,
+			target: "uuid.UUID", source: "string", canConvert: true,
+			expected: []string{
+				`v0, err := uuid.Parse(in.sourceField)`,
+				`if err != nil {`,
+				`	return err`,
+				`}`,
+				`out.targetField = v0`,
+			},
+		},
+		{
+			name: "string to uuid, parse mode must", parseMode: gen.ParseModeMust,
+			target: "uuid.UUID", source: "string", canConvert: true,
+			expected: []string{
+				`out.targetField = uuid.Must(uuid.Parse(in.sourceField))`,
+			},
+		},
+		{
+			name: "string to uuid, parse mode error without returning error", parseMode: gen.ParseModeError,
+			target: "uuid.UUID", source: "string", canConvert: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctc := gen.ConverterTestCase{
+				Name:               t.Name(),
+				TargetType:         tc.target,
+				SourceType:         tc.source,
+				Imports:            map[string]string{"uuid": "github.com/google/uuid"},
+				GoModRequires:      map[string]string{"github.com/google/uuid": "v1.6.0"},
+				Config:             &gen.Config{LibraryConverters: gen.LibraryConverterConfig{UUIDParse: tc.parseMode}},
+				ReturnError:        tc.returnError,
+				ExpectedCanConvert: tc.canConvert,
+				ExpectedCode:       tc.expected,
+			}
+
+			if tc.target == "uuid.UUID" {
+				ctc.ExpectedImports = []string{`import "github.com/google/uuid"`}
+			}
+
+			gen.ClearAllRegisteredConverters()
+			gen.RegisterConverter(Converter.UUIDString)
+
+			gen.Test.RunConverterTestCase(t, ctc, Converter.UUIDString)
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 const nilIfZeroType = "nil-if-zero"
 const useFunctionType = "use-function"
+const nullModeType = "null-mode"
 
 type FieldInterceptor interface {
 	GetType() string
@@ -24,6 +25,13 @@ type FieldInterceptor interface {
 	InterceptConvertField(converter Converter, ctx ConverterContext, target, source Symbol) jen.Code
 }
 
+// FieldConverterProvider is implemented by a FieldInterceptor which can convert a field itself,
+// e.g. use-function. FieldConverter is used for a field which no converter can convert, it
+// returns false if the interceptor cannot convert the field either.
+type FieldConverterProvider interface {
+	FieldConverter(ctx LookupContext, targetType, sourceType types.Type) (Converter, bool)
+}
+
 type FieldInterceptorProvider interface {
 	MakeFieldInterceptor(typ string, options map[string]any) FieldInterceptor
 }
@@ -53,10 +61,35 @@ func (d *defaultFieldInterceptorProvider) MakeFieldInterceptor(typ string, optio
 			}
 		}
 
+		var param string
+		pr, ok := options["param"]
+		if ok {
+			param, ok = pr.(string)
+			if !ok {
+				return nil
+			}
+		}
+
 		if method == "" {
-			return BuiltinFieldInterceptor.UseFunction(symbol)
+			return BuiltinFieldInterceptor.UseFunctionWithParam(param, symbol)
+		}
+		return BuiltinFieldInterceptor.UseMethodWithParam(param, symbol, method)
+
+	case nullModeType:
+		m, ok := options["mode"]
+		if !ok {
+			return nil
+		}
+		mode, ok := m.(string)
+		if !ok {
+			return nil
 		}
-		return BuiltinFieldInterceptor.UseMethod(symbol, method)
+
+		nullMode, ok := parseNullMode(mode)
+		if !ok {
+			return nil
+		}
+		return BuiltinFieldInterceptor.NullMode(nullMode)
 
 	default:
 		return nil
@@ -71,6 +104,15 @@ type builtinFieldInterceptor struct {
 	NilIfZero   FieldInterceptor
 	UseFunction func(symbol string) FieldInterceptor
 	UseMethod   func(variableSymbol string, methodName string) FieldInterceptor
+	NullMode    func(mode NullMode) FieldInterceptor
+
+	// UseFunctionWithParam is the same as UseFunction but the function receives the
+	// param of the mapper function before the source field, e.g. fn(tenantID, in.ID).
+	UseFunctionWithParam func(param string, symbol string) FieldInterceptor
+
+	// UseMethodWithParam is the same as UseMethod but the method receives the param
+	// of the mapper function before the source field.
+	UseMethodWithParam func(param string, variableSymbol string, methodName string) FieldInterceptor
 }
 
 var BuiltinFieldInterceptor = builtinFieldInterceptor{
@@ -86,6 +128,24 @@ var BuiltinFieldInterceptor = builtinFieldInterceptor{
 			method: methodName,
 		}
 	},
+	NullMode: func(mode NullMode) FieldInterceptor {
+		return &nullModeFieldInterceptor{
+			mode: mode,
+		}
+	},
+	UseFunctionWithParam: func(param string, symbol string) FieldInterceptor {
+		return &useFunctionFieldInterceptor{
+			symbol: symbol,
+			param:  param,
+		}
+	},
+	UseMethodWithParam: func(param string, variableSymbol string, methodName string) FieldInterceptor {
+		return &useFunctionFieldInterceptor{
+			symbol: variableSymbol,
+			method: methodName,
+			param:  param,
+		}
+	},
 }
 
 func DefaultFieldInterceptorProvider() FieldInterceptorProvider {
@@ -151,10 +211,14 @@ var _ FieldInterceptor = (*nilIfZeroFieldInterceptor)(nil)
 
 // ---
 
+// useFunctionFieldInterceptor converts a field via the given function or method, if param
+// is not empty the function has 2 params: the param of the mapper function and the source.
 type useFunctionFieldInterceptor struct {
-	symbol   string
-	method   string
-	function *funcConverter
+	symbol    string
+	method    string
+	param     string
+	paramType types.Type
+	function  *funcConverter
 }
 
 func (i *useFunctionFieldInterceptor) GetType() string {
@@ -162,16 +226,28 @@ func (i *useFunctionFieldInterceptor) GetType() string {
 }
 
 func (i *useFunctionFieldInterceptor) GetOptions() map[string]any {
-	return map[string]any{"symbol": i.symbol, "method": i.method}
+	if i.param == "" {
+		return map[string]any{"symbol": i.symbol, "method": i.method}
+	}
+	return map[string]any{"symbol": i.symbol, "method": i.method, "param": i.param}
 }
 
 func (i *useFunctionFieldInterceptor) Init(parser Parser, logger *slog.Logger) {
+	i.function = nil
+	i.paramType = nil
+
+	numParams := 1
+	if i.param != "" {
+		numParams = 2
+	}
+
 	cf := parseConverterFunctionConfigFromString(i.symbol)
 	fn, ok := parser.FindFunction(cf.PackagePath, cf.TypeName)
 	if ok {
-		if len(fn.Params) == 1 && len(fn.Results) == 1 {
+		if len(fn.Params) == numParams && len(fn.Results) == 1 {
+			i.paramType = i.firstParamType(fn)
 			i.function = &funcConverter{
-				sourceType: fn.Params[0],
+				sourceType: fn.Params[numParams-1],
 				targetType: fn.Results[0],
 				pkgPath:    fn.PackagePath,
 				funcName:   fn.Name,
@@ -188,9 +264,10 @@ func (i *useFunctionFieldInterceptor) Init(parser Parser, logger *slog.Logger) {
 					continue
 				}
 
-				if len(vfn.Params) == 1 && len(vfn.Results) == 1 {
+				if len(vfn.Params) == numParams && len(vfn.Results) == 1 {
+					i.paramType = i.firstParamType(vfn)
 					i.function = &funcConverter{
-						sourceType:   vfn.Params[0],
+						sourceType:   vfn.Params[numParams-1],
 						targetType:   vfn.Results[0],
 						variableName: &variableName,
 						pkgPath:      vfn.PackagePath,
@@ -206,11 +283,25 @@ func (i *useFunctionFieldInterceptor) Init(parser Parser, logger *slog.Logger) {
 	}
 }
 
-func (i *useFunctionFieldInterceptor) canConvert(targetType, sourceType types.Type) bool {
+func (i *useFunctionFieldInterceptor) firstParamType(fn FuncInfo) types.Type {
+	if i.param == "" {
+		return nil
+	}
+	return fn.Params[0]
+}
+
+func (i *useFunctionFieldInterceptor) canConvert(ctx LookupContext, targetType, sourceType types.Type) bool {
 	if i.function == nil {
 		return false
 	}
 
+	if i.param != "" {
+		param, ok := ctx.Param(i.param)
+		if !ok || !TypeUtil.IsIdentical(param.Type, i.paramType) {
+			return false
+		}
+	}
+
 	if !TypeUtil.IsIdentical(targetType, i.function.targetType) {
 		return false
 	}
@@ -221,20 +312,117 @@ func (i *useFunctionFieldInterceptor) canConvert(targetType, sourceType types.Ty
 	return true
 }
 
+// InterceptCanConvert does not use the function, it is used via FieldConverter if no
+// converter can convert the field.
 func (i *useFunctionFieldInterceptor) InterceptCanConvert(converter Converter, ctx LookupContext, targetType, sourceType types.Type) bool {
-	return i.canConvert(targetType, sourceType) || converter.CanConvert(ctx, targetType, sourceType)
+	return converter.CanConvert(ctx, targetType, sourceType)
 }
 
 func (i *useFunctionFieldInterceptor) InterceptConvertField(converter Converter, ctx ConverterContext, target, source Symbol) jen.Code {
-	if !i.canConvert(target.Type, source.Type) {
+	if !i.canConvert(ctx, target.Type, source.Type) {
 		return converter.ConvertField(ctx, target, source)
 	}
+	return i.convert(target, source)
+}
+
+func (i *useFunctionFieldInterceptor) FieldConverter(ctx LookupContext, targetType, sourceType types.Type) (Converter, bool) {
+	if !i.canConvert(ctx, targetType, sourceType) {
+		return nil, false
+	}
+	return &useFunctionConverter{interceptor: i}, true
+}
+
+func (i *useFunctionFieldInterceptor) convert(target, source Symbol) jen.Code {
+	/** generated code:
+	{target} = fn({source})
+
+	// or if param is used
+	{target} = fn(tenantID, {source})
+	*/
+	args := []jen.Code{source.Expr()}
+	if i.param != "" {
+		args = []jen.Code{jen.Id(i.param), source.Expr()}
+	}
 
 	code := target.Expr().Op("=")
 	if i.function.variableName != nil {
-		return code.Qual(i.function.pkgPath, *i.function.variableName).Dot(i.function.funcName).Params(source.Expr())
+		return code.Qual(i.function.pkgPath, *i.function.variableName).Dot(i.function.funcName).Params(args...)
 	}
-	return code.Qual(i.function.pkgPath, i.function.funcName).Params(source.Expr())
+	return code.Qual(i.function.pkgPath, i.function.funcName).Params(args...)
 }
 
 var _ FieldInterceptor = (*useFunctionFieldInterceptor)(nil)
+var _ FieldConverterProvider = (*useFunctionFieldInterceptor)(nil)
+
+// useFunctionConverter converts a field which no converter can convert via the function of a
+// use-function interceptor.
+type useFunctionConverter struct {
+	interceptor *useFunctionFieldInterceptor
+}
+
+func (c *useFunctionConverter) Init(_ Parser, _ Config, _ *slog.Logger) {
+	// no-op
+}
+
+func (c *useFunctionConverter) Info() ConverterInfo {
+	return ConverterInfo{Name: "use-function " + c.interceptor.symbol}
+}
+
+func (c *useFunctionConverter) CanConvert(ctx LookupContext, targetType, sourceType types.Type) bool {
+	return c.interceptor.canConvert(ctx, targetType, sourceType)
+}
+
+func (c *useFunctionConverter) ConvertField(ctx ConverterContext, target, source Symbol) jen.Code {
+	return ctx.Run(c, func() jen.Code {
+		return c.interceptor.convert(target, source)
+	})
+}
+
+var _ Converter = (*useFunctionConverter)(nil)
+
+// ---
+
+type nullModeFieldInterceptor struct {
+	mode NullMode
+}
+
+func (i *nullModeFieldInterceptor) GetType() string {
+	return nullModeType
+}
+
+func (i *nullModeFieldInterceptor) GetOptions() map[string]any {
+	var mode string
+	switch i.mode {
+	case NullModePointer:
+		mode = "pointer"
+	case NullModeZeroOnNull:
+		mode = "zero-on-null"
+	case NullModeValidIfNonZero:
+		mode = "valid-if-non-zero"
+	case NullModeAlwaysValid:
+		mode = "always-valid"
+	}
+	return map[string]any{"mode": mode}
+}
+
+func (i *nullModeFieldInterceptor) Init(_ Parser, _ *slog.Logger) {
+	// no-op
+}
+
+func (i *nullModeFieldInterceptor) InterceptCanConvert(converter Converter, ctx LookupContext, targetType, sourceType types.Type) bool {
+	c, ok := converter.(NullModeConverter)
+	if !ok {
+		return converter.CanConvert(ctx, targetType, sourceType)
+	}
+	return c.CanConvertWithNullMode(ctx, i.mode, targetType, sourceType)
+}
+
+func (i *nullModeFieldInterceptor) InterceptConvertField(converter Converter, ctx ConverterContext, target, source Symbol) jen.Code {
+	c, ok := converter.(NullModeConverter)
+	if !ok {
+		return converter.ConvertField(ctx, target, source)
+	}
+	return c.ConvertFieldWithNullMode(ctx, i.mode, target, source)
+}
+
+var _ FieldInterceptor = (*nullModeFieldInterceptor)(nil)
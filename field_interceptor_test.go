@@ -0,0 +1,56 @@
+package gomappergen
+
+import (
+	"go/types"
+	"testing"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_nullModeFieldInterceptor_pointer(t *testing.T) {
+	interceptor := DefaultFieldInterceptorProvider().MakeFieldInterceptor(nullModeType, map[string]any{"mode": "pointer"})
+
+	assert.Equal(t, BuiltinFieldInterceptor.NullMode(NullModePointer), interceptor)
+	assert.Equal(t, map[string]any{"mode": "pointer"}, interceptor.GetOptions())
+}
+
+func Test_findInterceptedConverter_nullMode(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register(&zeroOnNullConverter{})
+	scope := newConverterScope(registry.snapshot(nil, Config{}, NewNoopLogger()), DefaultLookUpCacheSize)
+
+	field := StructFieldInfo{Name: "Name", Type: types.Typ[types.String]}
+	target := Descriptor{structFieldInfo: &field}
+	source := Descriptor{structFieldInfo: &field}
+
+	_, ok := findConverter(scope, target, source, false, nil, NewNoopLogger())
+	assert.False(t, ok)
+
+	converter, ok := findInterceptedConverter(scope, target, source, false, nil, BuiltinFieldInterceptor.NullMode(NullModeZeroOnNull), NewNoopLogger())
+	assert.True(t, ok)
+	assert.IsType(t, &zeroOnNullConverter{}, converter)
+
+	_, ok = findInterceptedConverter(scope, target, source, false, nil, BuiltinFieldInterceptor.NullMode(NullModePointer), NewNoopLogger())
+	assert.False(t, ok)
+}
+
+// zeroOnNullConverter can only convert in the zero-on-null mode, the configured mode is
+// pointer.
+type zeroOnNullConverter struct {
+	dummyConverter
+}
+
+func (c *zeroOnNullConverter) CanConvert(ctx LookupContext, targetType, sourceType types.Type) bool {
+	return c.CanConvertWithNullMode(ctx, NullModePointer, targetType, sourceType)
+}
+
+func (c *zeroOnNullConverter) CanConvertWithNullMode(_ LookupContext, mode NullMode, _, _ types.Type) bool {
+	return mode == NullModeZeroOnNull
+}
+
+func (c *zeroOnNullConverter) ConvertFieldWithNullMode(_ ConverterContext, _ NullMode, _, _ Symbol) jen.Code {
+	return nil
+}
+
+var _ NullModeConverter = (*zeroOnNullConverter)(nil)
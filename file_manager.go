@@ -2,7 +2,10 @@ package gomappergen
 
 import (
 	"fmt"
+	"maps"
+	"path"
 	"path/filepath"
+	"sync"
 
 	"github.com/dave/jennifer/jen"
 	"golang.org/x/tools/go/packages"
@@ -21,8 +24,11 @@ func Version() string {
 }
 
 type FileManager interface {
+	// MakeJenFile returns the file of the generated code, which is config.Output.FileName in
+	// config.Output.Dir if it is set, otherwise in the current package.
 	MakeJenFile(parser Parser, currentPkg *packages.Package, config PackageConfig) *jen.File
 
+	// JenFiles returns the files made so far, keyed by the path relative to the source dir.
 	JenFiles() map[string]*jen.File
 }
 
@@ -35,30 +41,47 @@ func DefaultFileManager() FileManager {
 
 type fileManagerImpl struct {
 	version string
-	files   map[string]*jen.File
+
+	mu    sync.Mutex
+	files map[string]*jen.File
 }
 
 func (fm *fileManagerImpl) JenFiles() map[string]*jen.File {
-	return fm.files
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	return maps.Clone(fm.files)
 }
 
 func (fm *fileManagerImpl) MakeJenFile(parser Parser, currentPkg *packages.Package, config PackageConfig) *jen.File {
-	target := filepath.Join(currentPkg.Dir, config.Output.FileName)
+	dir := currentPkg.Dir
+	if config.Output.Dir != "" {
+		dir = filepath.Join(parser.SourceDir(), config.Output.Dir)
+	}
+
+	target := filepath.Join(dir, config.Output.FileName)
 	fullPath, err := filepath.Rel(parser.SourceDir(), target)
 	if err != nil {
 		panic(err)
 	}
 
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+
 	v, have := fm.files[fullPath]
 	if have {
 		return v
 	}
 
+	pkgPath, currentPkgName := currentPkg.PkgPath, currentPkg.Name
+	if config.Output.Dir != "" {
+		pkgPath, currentPkgName = config.Output.ImportPath, path.Base(config.Output.ImportPath)
+	}
+
 	pkgName := replacePlaceholders(config.Output.PkgName, map[string]string{
-		Placeholder.CurrentPackageName: currentPkg.Name,
+		Placeholder.CurrentPackageName: currentPkgName,
 	})
 
-	jf := jen.NewFilePathName(currentPkg.PkgPath, pkgName)
+	jf := jen.NewFilePathName(pkgPath, pkgName)
 	jf.HeaderComment(fmt.Sprintf("Code generated by %v - %v, DO NOT EDIT.", BinaryName, fm.version))
 	fm.files[fullPath] = jf
 
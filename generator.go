@@ -2,9 +2,14 @@ package gomappergen
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"go/token"
+	"go/types"
 	"log/slog"
+	"maps"
 	"math"
+	"path"
 	"slices"
 	"strings"
 
@@ -16,25 +21,80 @@ import (
 type generatorImpl struct {
 	parser      Parser
 	fileManager FileManager
+	scope       *converterScope
 	logger      *slog.Logger
 }
 
 func (g *generatorImpl) Generate(currentPkg *packages.Package, configs []PackageConfig) error {
 	for _, cf := range configs {
-		file := g.fileManager.MakeJenFile(g.parser, currentPkg, cf)
-		if file == nil {
-			continue
+		if cf.Output.Dir != "" && cf.Output.ImportPath == "" {
+			return &GenerateError{
+				PkgPath: currentPkg.PkgPath,
+				Err:     fmt.Errorf("output.import_path is required when output.dir %q is set", cf.Output.Dir),
+			}
 		}
 
-		if err := generateMapper(g.parser, file, currentPkg, cf, g.logger); err != nil {
-			return err
+		files := &genFiles{fileManager: g.fileManager, parser: g.parser, currentPkg: currentPkg, config: cf}
+		if err := generateMapper(g.parser, files, g.scope, currentPkg, cf, g.logger); err != nil {
+			ge := &GenerateError{Err: err}
+			errors.As(err, &ge)
+			ge.PkgPath = currentPkg.PkgPath
+			return ge
 		}
 	}
 	return nil
 }
 
+func (g *generatorImpl) Stats() GeneratorStats {
+	hits, misses := g.scope.lookUpCache().stats()
+	return GeneratorStats{LookUpCacheHits: hits, LookUpCacheMisses: misses}
+}
+
 var _ Generator = (*generatorImpl)(nil)
 
+// genFiles makes the files which the generated code of a PackageConfig is written to, a file
+// is made on demand so a file without generated code is not made.
+type genFiles struct {
+	fileManager FileManager
+	parser      Parser
+	currentPkg  *packages.Package
+	config      PackageConfig
+}
+
+// main returns the file of config.Output.FileName.
+func (f *genFiles) main() *jen.File {
+	return f.fileManager.MakeJenFile(f.parser, f.currentPkg, f.config)
+}
+
+// hasTest reports whether tests are generated.
+func (f *genFiles) hasTest() bool {
+	return f.config.GenerateTest && f.config.Output.TestFileName != ""
+}
+
+// test returns the file of config.Output.TestFileName in the package of the main file, or nil
+// if tests are not generated.
+func (f *genFiles) test() *jen.File {
+	if !f.hasTest() {
+		return nil
+	}
+
+	cf := f.config
+	cf.Output.FileName = cf.Output.TestFileName
+	return f.fileManager.MakeJenFile(f.parser, f.currentPkg, cf)
+}
+
+// of returns the file of the mapping code of a struct, which is the main file unless the
+// output is split per struct.
+func (f *genFiles) of(vars map[string]string) *jen.File {
+	if f.config.Output.Split != OutputSplitPerStruct {
+		return f.main()
+	}
+
+	cf := f.config
+	cf.Output.FileName = replacePlaceholders(cf.Output.StructFileName, vars)
+	return f.fileManager.MakeJenFile(f.parser, f.currentPkg, cf)
+}
+
 type convertibleField struct {
 	index            int
 	targetFieldName  string
@@ -56,6 +116,23 @@ func (f *convertibleField) PerformConvertField(ctx *converterContext) jen.Code {
 	return f.interceptor.InterceptConvertField(f.converter, ctx, f.targetSymbol, f.sourceSymbol)
 }
 
+// genMapSource is a source struct of a map function, there is more than one source in a
+// multi-source mapping.
+type genMapSource struct {
+	paramName  string
+	pkgPath    string
+	structInfo *StructInfo
+}
+
+// sourceFieldRef refers to the field of a source which is mapped to a target field, name
+// is empty if there is no matched field. A target field which is mapped to a param of the
+// map function, e.g. $tenantID, refers to the param instead of a source.
+type sourceFieldRef struct {
+	source *genMapSource
+	name   string
+	param  *Symbol
+}
+
 type genMapFunc struct {
 	name                string
 	funcName            string
@@ -64,24 +141,34 @@ type genMapFunc struct {
 	targetParamName     string
 	targetStruct        *StructInfo
 	targetPointer       bool
-	sourcePkgPath       string
-	sourceParamName     string
-	sourceStruct        *StructInfo
+	sources             []genMapSource
 	sourcePointer       bool
+	params              []Symbol
 	mappedFields        []convertibleField
 	missingFields       []string
 	unconvertibleFields []string
 	targetFieldsIndex   map[string]int
 	sourceFieldsIndex   map[string]int
+	matchedFields       map[string]string
+	exportedFieldsOnly  bool
+	returnError         bool
+	file                *jen.File
+	roundTripReport     *roundTripReport
 }
 
 func (mf *genMapFunc) paramsAndResults() ([]jen.Code, []jen.Code) {
 	var params, result []jen.Code
 
-	if mf.sourcePointer {
-		params = append(params, jen.Id(mf.sourceParamName).Add(jen.Op("*").Add(GeneratorUtil.TypeToJenCode(mf.sourceStruct.Type))))
-	} else {
-		params = append(params, jen.Id(mf.sourceParamName).Add(GeneratorUtil.TypeToJenCode(mf.sourceStruct.Type)))
+	for _, source := range mf.sources {
+		if mf.sourcePointer {
+			params = append(params, jen.Id(source.paramName).Add(jen.Op("*").Add(GeneratorUtil.TypeToJenCode(source.structInfo.Type))))
+		} else {
+			params = append(params, jen.Id(source.paramName).Add(GeneratorUtil.TypeToJenCode(source.structInfo.Type)))
+		}
+	}
+
+	for _, param := range mf.params {
+		params = append(params, jen.Id(param.VarName).Add(GeneratorUtil.TypeToJenCode(param.Type)))
 	}
 
 	if mf.targetPointer {
@@ -90,22 +177,119 @@ func (mf *genMapFunc) paramsAndResults() ([]jen.Code, []jen.Code) {
 		result = append(result, jen.Add(GeneratorUtil.TypeToJenCode(mf.targetStruct.Type)))
 	}
 
+	if mf.returnError {
+		result = append(result, jen.Error())
+	}
+
 	return params, result
 }
 
+// decoratorParams returns the parameters of the decorate function, which are pointers of
+// the sources and the target.
+func (mf *genMapFunc) decoratorParams() []jen.Code {
+	var params []jen.Code
+	for _, source := range mf.sources {
+		params = append(params, jen.Id(source.paramName).Add(jen.Op("*").Add(GeneratorUtil.TypeToJenCode(source.structInfo.Type))))
+	}
+	return append(params, jen.Id("out").Add(jen.Op("*").Add(GeneratorUtil.TypeToJenCode(mf.targetStruct.Type))))
+}
+
+// decoratorArgs returns the arguments which are passed to the decorate function.
+func (mf *genMapFunc) decoratorArgs() []jen.Code {
+	var args []jen.Code
+	for _, source := range mf.sources {
+		if mf.sourcePointer {
+			args = append(args, jen.Id(source.paramName))
+		} else {
+			args = append(args, jen.Op("&").Id(source.paramName))
+		}
+	}
+	return append(args, jen.Op("&").Id(mf.targetParamName))
+}
+
+// goDoc returns the doc comment of the map function.
+func (mf *genMapFunc) goDoc(currentPkg *packages.Package) string {
+	targetName := GeneratorUtil.SimpleNameWithPkg(currentPkg, mf.targetStruct.Type)
+	if len(mf.sources) == 1 {
+		sourceName := GeneratorUtil.SimpleNameWithPkg(currentPkg, mf.sources[0].structInfo.Type)
+		return fmt.Sprintf("%v converts a %v value into a %v value.", mf.funcName, sourceName, targetName)
+	}
+
+	var sourceNames []string
+	for _, source := range mf.sources {
+		sourceNames = append(sourceNames, GeneratorUtil.SimpleNameWithPkg(currentPkg, source.structInfo.Type))
+	}
+	last := len(sourceNames) - 1
+	sources := strings.Join(sourceNames[:last], ", ") + " and " + sourceNames[last]
+	return fmt.Sprintf("%v converts %v values into a %v value.", mf.funcName, sources, targetName)
+}
+
+// matchedTargetField returns the target field which is matched with the given source
+// field, whether or not it can be converted.
+func (mf *genMapFunc) matchedTargetField(sourceField string) (string, bool) {
+	for _, target := range slices.Sorted(maps.Keys(mf.matchedFields)) {
+		if mf.matchedFields[target] == sourceField {
+			return target, true
+		}
+	}
+	return "", false
+}
+
+// roundTripComment returns the round-trip report in the GoDoc comment, or nil if there is
+// no report. afterGoDoc adds an empty line which separates the report from the GoDoc.
+func (mf *genMapFunc) roundTripComment(afterGoDoc bool) jen.Code {
+	if mf.roundTripReport == nil {
+		return nil
+	}
+
+	var lines []jen.Code
+	if afterGoDoc {
+		lines = append(lines, jen.Comment(""), jen.Line())
+	}
+	for i, line := range mf.roundTripReport.comment() {
+		if i > 0 {
+			lines = append(lines, jen.Line())
+		}
+		lines = append(lines, jen.Comment(line))
+	}
+	return jen.Add(lines...)
+}
+
+// zeroResult returns the target value which is returned together with an error.
+func (mf *genMapFunc) zeroResult() jen.Code {
+	if mf.targetPointer {
+		return jen.Nil()
+	}
+	return jen.Add(GeneratorUtil.TypeToJenCode(mf.targetStruct.Type)).Values()
+}
+
+// returnResults returns the statement which returns the mapped target value.
+func (mf *genMapFunc) returnResults() jen.Code {
+	var results []jen.Code
+	if mf.targetPointer {
+		results = append(results, jen.Op("&").Id(mf.targetParamName))
+	} else {
+		results = append(results, jen.Id(mf.targetParamName))
+	}
+
+	if mf.returnError {
+		results = append(results, jen.Nil())
+	}
+	return jen.Line().Return(results...)
+}
+
 func (mf *genMapFunc) appendUnconvertibleField(field string) {
 	mf.unconvertibleFields = append(mf.unconvertibleFields, field)
 }
 
-func generateMapper(parser Parser, file *jen.File, currentPkg *packages.Package, config PackageConfig, logger *slog.Logger) error {
+func generateMapper(parser Parser, files *genFiles, scope *converterScope, currentPkg *packages.Package, config PackageConfig, logger *slog.Logger) error {
 	ctx := &converterContext{
 		Context:       context.Background(),
-		lookupContext: emptyLookupContext(logger),
-		jenFile:       file,
+		lookupContext: emptyLookupContext(scope, logger),
 		parser:        parser,
 	}
 
-	mapFuncs, err := collectMapFuncs(ctx, currentPkg, config, logger)
+	mapFuncs, err := collectMapFuncs(ctx, files, currentPkg, config, logger)
 	if err != nil {
 		return err
 	}
@@ -121,33 +305,56 @@ func generateMapper(parser Parser, file *jen.File, currentPkg *packages.Package,
 
 	logger.Info(fmt.Sprintf("\tthere are %d map functions matched with configuration.", len(mapFuncs)))
 	for _, mf := range mapFuncs {
-		logger.Info(fmt.Sprintf("\t\t- %s(%s) %s", util.ColorBlue(mf.funcName), mf.sourceStruct.Type.String(), mf.targetStruct.Type.String()))
+		var sourceTypes []string
+		for _, source := range mf.sources {
+			sourceTypes = append(sourceTypes, source.structInfo.Type.String())
+		}
+		logger.Info(fmt.Sprintf("\t\t- %s(%s) %s", util.ColorBlue(mf.funcName), strings.Join(sourceTypes, ", "), mf.targetStruct.Type.String()))
+	}
+	reportRoundTrips(config, mapFuncs, logger)
+
+	// the package which the generated code lives in, types of other packages are qualified
+	outputPkg := currentPkg
+	if config.Output.Dir != "" {
+		outputPkg = &packages.Package{Name: path.Base(config.Output.ImportPath), PkgPath: config.Output.ImportPath}
 	}
 
 	switch config.Mode {
 	case ModeFunctions:
 		logger.Info("\tgenerating mode functions...")
-		generateMapperFunctions(ctx, currentPkg, config, mapFuncs)
+		generateMapperFunctions(ctx, outputPkg, config, mapFuncs)
 
 	default:
 		logger.Info("\tgenerating mode types...")
-		generateMapperInterface(file, currentPkg, config, mapFuncs, logger)
+		file := files.main()
+		if file == nil {
+			return nil
+		}
+
+		ctx.setJenFile(file)
+		generateMapperInterface(file, outputPkg, config, mapFuncs, logger)
 		generateDecoratorInterface(ctx, config, mapFuncs, logger)
 		generateMapperConstructor(ctx, config, mapFuncs, logger)
 		generateMapperImplementation(ctx, config, mapFuncs, logger)
 		generateDecoratorNoOp(ctx, config, mapFuncs, logger)
 		generateCompileTimeCheck(file, config, mapFuncs, logger)
 	}
+
+	if files.hasTest() {
+		generateRoundTripTests(files.test, outputPkg, config, mapFuncs, logger)
+	}
 	logger.Info("\tfinished")
 
 	return nil
 }
 
-func generateMapperFunctions(ctx *converterContext, currentPkg *packages.Package, config PackageConfig, mapFuncs []*genMapFunc) {
-	file := ctx.JenFile()
-
+func generateMapperFunctions(ctx *converterContext, outputPkg *packages.Package, config PackageConfig, mapFuncs []*genMapFunc) {
 	for _, mf := range mapFuncs {
+		file := mf.file
+		ctx.setJenFile(file)
 		ctx.resetVarCount()
+		ctx.setReturnError(mf.returnError, mf.zeroResult())
+		ctx.setParams(mf.params)
 
 		params, results := mf.paramsAndResults()
 
@@ -159,13 +366,13 @@ func generateMapperFunctions(ctx *converterContext, currentPkg *packages.Package
 		}
 
 		if useDecorator {
-			params = append(params,
-				jen.Id("decorators").Op("...").Func().
-					Params(
-						jen.Op("*").Add(GeneratorUtil.TypeToJenCode(mf.sourceStruct.Type)),
-						jen.Op("*").Add(GeneratorUtil.TypeToJenCode(mf.targetStruct.Type)),
-					),
-			)
+			var decoratorTypes []jen.Code
+			for _, source := range mf.sources {
+				decoratorTypes = append(decoratorTypes, jen.Op("*").Add(GeneratorUtil.TypeToJenCode(source.structInfo.Type)))
+			}
+			decoratorTypes = append(decoratorTypes, jen.Op("*").Add(GeneratorUtil.TypeToJenCode(mf.targetStruct.Type)))
+
+			params = append(params, jen.Id("decorators").Op("...").Func().Params(decoratorTypes...))
 		}
 
 		var body []jen.Code
@@ -186,37 +393,21 @@ func generateMapperFunctions(ctx *converterContext, currentPkg *packages.Package
 		}
 
 		if useDecorator {
-			var decoratorParams []jen.Code
-
-			if mf.sourcePointer {
-				decoratorParams = append(decoratorParams, jen.Id(mf.sourceParamName))
-			} else {
-				decoratorParams = append(decoratorParams, jen.Op("&").Id(mf.sourceParamName))
-			}
-			decoratorParams = append(decoratorParams, jen.Op("&").Id(mf.targetParamName))
-
 			code := jen.
 				For(jen.List(jen.Id("_"), jen.Id("decorate")).Op(":=").Range().Id("decorators")).
-				Block(jen.Id("decorate").Call(decoratorParams...))
+				Block(jen.Id("decorate").Call(mf.decoratorArgs()...))
 
 			body = append(body, jen.Line())
 			body = append(body, code)
 		}
 
-		if mf.targetPointer {
-			body = append(body, jen.Line().Return(jen.Op("&").Id(mf.targetParamName)))
-		} else {
-			body = append(body, jen.Line().Return(jen.Id(mf.targetParamName)))
-		}
+		body = append(body, mf.returnResults())
 
 		if config.GenerateGoDoc {
-			comment := fmt.Sprintf(
-				"%v converts a %v value into a %v value.",
-				mf.funcName,
-				GeneratorUtil.SimpleNameWithPkg(currentPkg, mf.sourceStruct.Type),
-				GeneratorUtil.SimpleNameWithPkg(currentPkg, mf.targetStruct.Type),
-			)
-			file.Comment(comment)
+			file.Comment(mf.goDoc(outputPkg))
+		}
+		if comment := mf.roundTripComment(config.GenerateGoDoc); comment != nil {
+			file.Add(comment)
 		}
 
 		file.Func().
@@ -228,21 +419,17 @@ func generateMapperFunctions(ctx *converterContext, currentPkg *packages.Package
 	}
 }
 
-func generateMapperInterface(file *jen.File, currentPkg *packages.Package, config PackageConfig, mapFuncs []*genMapFunc, logger *slog.Logger) {
+func generateMapperInterface(file *jen.File, outputPkg *packages.Package, config PackageConfig, mapFuncs []*genMapFunc, logger *slog.Logger) {
 	var signatures []jen.Code
 
 	for _, mf := range mapFuncs {
 		params, results := mf.paramsAndResults()
 
 		if config.GenerateGoDoc {
-			comment := fmt.Sprintf(
-				"%v converts a %v value into a %v value.",
-				mf.funcName,
-				GeneratorUtil.SimpleNameWithPkg(currentPkg, mf.sourceStruct.Type),
-				GeneratorUtil.SimpleNameWithPkg(currentPkg, mf.targetStruct.Type),
-			)
-
-			signatures = append(signatures, GeneratorUtil.WrapComment(comment))
+			signatures = append(signatures, GeneratorUtil.WrapComment(mf.goDoc(outputPkg)))
+		}
+		if comment := mf.roundTripComment(config.GenerateGoDoc); comment != nil {
+			signatures = append(signatures, comment)
 		}
 		signatures = append(signatures, jen.Id(mf.funcName).Params(params...).Params(results...).Line())
 	}
@@ -259,11 +446,7 @@ func generateDecoratorInterface(ctx *converterContext, config PackageConfig, map
 	var signatures []jen.Code
 
 	for _, mf := range mapFuncs {
-		var params []jen.Code
-		params = append(params, jen.Id("in").Add(jen.Op("*").Add(GeneratorUtil.TypeToJenCode(mf.sourceStruct.Type))))
-		params = append(params, jen.Id("out").Add(jen.Op("*").Add(GeneratorUtil.TypeToJenCode(mf.targetStruct.Type))))
-
-		signatures = append(signatures, jen.Id(mf.decorateFuncName).Params(params...).Params().Line())
+		signatures = append(signatures, jen.Id(mf.decorateFuncName).Params(mf.decoratorParams()...).Params().Line())
 	}
 
 	ctx.jenFile.Type().Id(config.DecoratorInterfaceName).Interface(signatures...).Line().Line()
@@ -297,7 +480,10 @@ func generateMapperImplementation(ctx *converterContext, config PackageConfig, m
 	}
 
 	for _, mf := range mapFuncs {
+		ctx.setJenFile(mf.file)
 		ctx.resetVarCount()
+		ctx.setReturnError(mf.returnError, mf.zeroResult())
+		ctx.setParams(mf.params)
 
 		params, results := mf.paramsAndResults()
 
@@ -321,18 +507,9 @@ func generateMapperImplementation(ctx *converterContext, config PackageConfig, m
 		}
 
 		if shouldEmitDecoratorCall {
-			var decoratorParams []jen.Code
-
-			if mf.sourcePointer {
-				decoratorParams = append(decoratorParams, jen.Id(mf.sourceParamName))
-			} else {
-				decoratorParams = append(decoratorParams, jen.Op("&").Id(mf.sourceParamName))
-			}
-			decoratorParams = append(decoratorParams, jen.Op("&").Id(mf.targetParamName))
-
 			body = append(body, jen.Line())
 			body = append(body, jen.If(jen.Id("m").Dot("decorator").Op("!=").Nil()).BlockFunc(func(g *jen.Group) {
-				g.Id("m").Dot("decorator").Dot(mf.decorateFuncName).Params(decoratorParams...)
+				g.Id("m").Dot("decorator").Dot(mf.decorateFuncName).Params(mf.decoratorArgs()...)
 			}))
 
 			// the decorator call is needed and has missing comments already
@@ -347,13 +524,9 @@ func generateMapperImplementation(ctx *converterContext, config PackageConfig, m
 			}
 		}
 
-		if mf.targetPointer {
-			body = append(body, jen.Line().Return(jen.Op("&").Id(mf.targetParamName)))
-		} else {
-			body = append(body, jen.Line().Return(jen.Id(mf.targetParamName)))
-		}
+		body = append(body, mf.returnResults())
 
-		file.Func().
+		mf.file.Func().
 			Params(jen.Id("m").Op("*").Id(config.ImplementationName)).
 			Id(mf.funcName).
 			Params(params...).
@@ -361,6 +534,7 @@ func generateMapperImplementation(ctx *converterContext, config PackageConfig, m
 			Block(body...).
 			Line()
 	}
+	ctx.setJenFile(file)
 	logger.Info(fmt.Sprintf("\tgenerated implementation %s", util.ColorBlue(config.ImplementationName)))
 }
 
@@ -375,9 +549,7 @@ func generateDecoratorNoOp(ctx *converterContext, config PackageConfig, mapFuncs
 		ctx.resetVarCount()
 
 		var body []jen.Code
-		var params []jen.Code
-		params = append(params, jen.Id("in").Add(jen.Op("*").Add(GeneratorUtil.TypeToJenCode(mf.sourceStruct.Type))))
-		params = append(params, jen.Id("out").Add(jen.Op("*").Add(GeneratorUtil.TypeToJenCode(mf.targetStruct.Type))))
+		params := mf.decoratorParams()
 
 		mau := makeMissingAndUnconvertibleFields(mf)
 		if len(mau) > 0 {
@@ -434,9 +606,20 @@ func makeMissingAndUnconvertibleFields(mf *genMapFunc) []jen.Code {
 	return code
 }
 
-func collectMapFuncs(ctx *converterContext, currentPkg *packages.Package, config PackageConfig, logger *slog.Logger) ([]*genMapFunc, error) {
-	var mapFuncs []*genMapFunc
+func collectMapFuncs(ctx *converterContext, files *genFiles, currentPkg *packages.Package, config PackageConfig, logger *slog.Logger) (mapFuncs []*genMapFunc, err error) {
+	// a converter may panic on an unexpected type, the mapper which caused it is reported
+	var mapperName string
+	defer func() {
+		if r := recover(); r != nil {
+			mapFuncs, err = nil, &GenerateError{MapperName: mapperName, Err: fmt.Errorf("panic: %v", r)}
+		}
+	}()
+
+	// the generated code in another package cannot access unexported fields
+	exportedFieldsOnly := config.Output.PkgPath(currentPkg.PkgPath) != currentPkg.PkgPath
+
 	for _, cf := range config.Structs {
+		mapperName = cf.MapperName
 		var vars = map[string]string{
 			Placeholder.CurrentPackage:     currentPkg.PkgPath,
 			Placeholder.CurrentPackageName: currentPkg.Name,
@@ -454,6 +637,18 @@ func collectMapFuncs(ctx *converterContext, currentPkg *packages.Package, config
 			continue
 		}
 
+		file := files.of(vars)
+		if file == nil {
+			continue
+		}
+
+		if cf.IsMultiSource() {
+			if mapFunc := collectMultiSourceMapFunc(ctx, cf, vars, &targetStruct, file, exportedFieldsOnly, logger); mapFunc != nil {
+				mapFuncs = append(mapFuncs, mapFunc)
+			}
+			continue
+		}
+
 		sourceStruct, ok := ctx.Parser().FindStruct(replacePlaceholders(cf.SourcePkgPath, vars), cf.SourceStructName)
 		if !ok {
 			logger.Warn(
@@ -464,19 +659,8 @@ func collectMapFuncs(ctx *converterContext, currentPkg *packages.Package, config
 			continue
 		}
 
-		var useTargetPointer, useSourcePointer bool
-		switch cf.Pointer {
-		case PointerSourceOnly:
-			useSourcePointer = true
-		case PointerTargetOnly:
-			useTargetPointer = true
-		case PointerBoth:
-			useTargetPointer = true
-			useSourcePointer = true
-		default:
-			useTargetPointer = false
-			useSourcePointer = false
-		}
+		params := collectParams(ctx, cf, vars, []string{"in"}, logger)
+		useTargetPointer, useSourcePointer := usePointers(cf.Pointer)
 
 		if cf.GenerateSourceToTarget {
 			toTargetFuncName := replacePlaceholders(cf.SourceToTargetFuncName, vars)
@@ -486,22 +670,24 @@ func collectMapFuncs(ctx *converterContext, currentPkg *packages.Package, config
 			decorateToTargetFuncName := replacePlaceholders(cf.DecorateFuncName, tv)
 
 			mapFunc := genMapFunc{
-				name:              cf.MapperName + "-SourceToTarget",
-				funcName:          toTargetFuncName,
-				decorateFuncName:  decorateToTargetFuncName,
-				targetParamName:   "out",
-				targetPkgPath:     cf.TargetPkgPath,
-				targetStruct:      &targetStruct,
-				targetPointer:     useTargetPointer,
-				sourceParamName:   "in",
-				sourcePkgPath:     cf.SourcePkgPath,
-				sourceStruct:      &sourceStruct,
-				sourcePointer:     useSourcePointer,
-				targetFieldsIndex: makeFieldsIndex(targetStruct.Fields),
-				sourceFieldsIndex: makeFieldsIndex(sourceStruct.Fields),
+				name:               cf.MapperName + "-SourceToTarget",
+				funcName:           toTargetFuncName,
+				decorateFuncName:   decorateToTargetFuncName,
+				targetParamName:    "out",
+				targetPkgPath:      cf.TargetPkgPath,
+				targetStruct:       &targetStruct,
+				targetPointer:      useTargetPointer,
+				sources:            []genMapSource{{paramName: "in", pkgPath: cf.SourcePkgPath, structInfo: &sourceStruct}},
+				sourcePointer:      useSourcePointer,
+				params:             params,
+				targetFieldsIndex:  makeFieldsIndex(targetStruct.Fields),
+				sourceFieldsIndex:  makeFieldsIndex(sourceStruct.Fields),
+				exportedFieldsOnly: exportedFieldsOnly,
+				returnError:        cf.ReturnError,
+				file:               file,
 			}
 
-			fillMapFunc(ctx, &mapFunc, targetStruct.Fields, sourceStruct.Fields, cf.Fields, cf.UseGetter, cf.TargetFieldInterceptors)
+			fillMapFunc(ctx, &mapFunc, cf.Fields, cf.UseGetter, cf.TargetFieldInterceptors)
 			mapFuncs = append(mapFuncs, &mapFunc)
 		}
 
@@ -513,71 +699,243 @@ func collectMapFuncs(ctx *converterContext, currentPkg *packages.Package, config
 			decorateFromTargetFuncName := replacePlaceholders(cf.DecorateFuncName, fv)
 
 			mapFunc := genMapFunc{
-				name:              cf.MapperName + "-TargetToSource",
-				funcName:          fromTargetFuncName,
-				decorateFuncName:  decorateFromTargetFuncName,
-				targetParamName:   "out",
-				targetPkgPath:     cf.TargetPkgPath,
-				targetStruct:      &sourceStruct,
-				targetPointer:     useSourcePointer,
-				sourceParamName:   "in",
-				sourcePkgPath:     cf.SourcePkgPath,
-				sourceStruct:      &targetStruct,
-				sourcePointer:     useTargetPointer,
-				targetFieldsIndex: makeFieldsIndex(sourceStruct.Fields),
-				sourceFieldsIndex: makeFieldsIndex(targetStruct.Fields),
+				name:               cf.MapperName + "-TargetToSource",
+				funcName:           fromTargetFuncName,
+				decorateFuncName:   decorateFromTargetFuncName,
+				targetParamName:    "out",
+				targetPkgPath:      cf.TargetPkgPath,
+				targetStruct:       &sourceStruct,
+				targetPointer:      useSourcePointer,
+				sources:            []genMapSource{{paramName: "in", pkgPath: cf.SourcePkgPath, structInfo: &targetStruct}},
+				sourcePointer:      useTargetPointer,
+				params:             params,
+				targetFieldsIndex:  makeFieldsIndex(sourceStruct.Fields),
+				sourceFieldsIndex:  makeFieldsIndex(targetStruct.Fields),
+				exportedFieldsOnly: exportedFieldsOnly,
+				returnError:        cf.ReturnError,
+				file:               file,
 			}
 
-			fillMapFunc(ctx, &mapFunc, sourceStruct.Fields, targetStruct.Fields, cf.Fields.Flip(), cf.UseGetter, cf.SourceFieldInterceptors)
+			fillMapFunc(ctx, &mapFunc, cf.Fields.Flip(), cf.UseGetter, cf.SourceFieldInterceptors)
 			mapFuncs = append(mapFuncs, &mapFunc)
 		}
 	}
 	return mapFuncs, nil
 }
 
-func fillMapFunc(
-	ctx *converterContext,
-	mapFunc *genMapFunc,
-	targetFields, sourceFields map[string]StructFieldInfo,
-	config FieldConfig,
-	useGetter bool,
-	interceptors map[string]FieldInterceptor,
-) {
-	samePkg := mapFunc.targetPkgPath == mapFunc.sourcePkgPath
-	mappedFields := mapFieldNames(targetFields, sourceFields, config, samePkg)
-	for target, source := range mappedFields {
-		if source == "" {
-			if samePkg {
-				mapFunc.missingFields = append(mapFunc.missingFields, target)
-				continue
-			}
+// collectMultiSourceMapFunc returns the source-to-target map function of a multi-source
+// mapping, or nil if a source struct cannot be found.
+func collectMultiSourceMapFunc(ctx *converterContext, cf StructConfig, vars map[string]string, targetStruct *StructInfo, file *jen.File, exportedFieldsOnly bool, logger *slog.Logger) *genMapFunc {
+	if !cf.GenerateSourceToTarget {
+		return nil
+	}
+
+	var sources []genMapSource
+	var sourceParamNames []string
+	for _, v := range cf.Sources {
+		// the names are used by the generated code, e.g. m is the receiver in mode types
+		if !token.IsIdentifier(v.ParamName) || slices.Contains([]string{"m", "out", "decorators", "decorate"}, v.ParamName) {
+			logger.Warn(
+				"\tinvalid source param name",
+				slog.String("target_struct_name", cf.TargetStructName),
+				slog.String("source_param_name", v.ParamName),
+			)
+			return nil
+		}
+
+		sourceStruct, ok := findSourceStruct(ctx.Parser(), replacePlaceholders(v.PkgPath, vars), v.StructName)
+		if !ok {
+			logger.Warn(
+				"\tcould not find source struct",
+				slog.String("source_param_name", v.ParamName),
+				slog.String("source_struct_name", v.StructName),
+				slog.String("source_pkg", v.PkgPath),
+			)
+			return nil
+		}
+		sources = append(sources, genMapSource{paramName: v.ParamName, pkgPath: v.PkgPath, structInfo: &sourceStruct})
+		sourceParamNames = append(sourceParamNames, v.ParamName)
+	}
+
+	useTargetPointer, useSourcePointer := usePointers(cf.Pointer)
+
+	toTargetFuncName := replacePlaceholders(cf.SourceToTargetFuncName, vars)
+	vars[Placeholder.FunctionName] = toTargetFuncName
+
+	mapFunc := genMapFunc{
+		name:               cf.MapperName + "-SourceToTarget",
+		funcName:           toTargetFuncName,
+		decorateFuncName:   replacePlaceholders(cf.DecorateFuncName, vars),
+		targetParamName:    "out",
+		targetPkgPath:      cf.TargetPkgPath,
+		targetStruct:       targetStruct,
+		targetPointer:      useTargetPointer,
+		sources:            sources,
+		sourcePointer:      useSourcePointer,
+		params:             collectParams(ctx, cf, vars, sourceParamNames, logger),
+		targetFieldsIndex:  makeFieldsIndex(targetStruct.Fields),
+		exportedFieldsOnly: exportedFieldsOnly,
+		returnError:        cf.ReturnError,
+		file:               file,
+	}
 
-			if targetInfo, ok := targetFields[target]; ok && targetInfo.IsExported {
-				mapFunc.missingFields = append(mapFunc.missingFields, target)
+	fillMapFunc(ctx, &mapFunc, cf.Fields, cf.UseGetter, cf.TargetFieldInterceptors)
+	return &mapFunc
+}
+
+// findSourceStruct finds a struct by the package path, or by the package name if there is
+// a source package with the name, e.g. db for github.com/acme/app/db.
+func findSourceStruct(parser Parser, pkgPath string, name string) (StructInfo, bool) {
+	if !strings.Contains(pkgPath, "/") {
+		for _, pkg := range parser.SourcePackages() {
+			if pkg.Name == pkgPath && pkg.PkgPath != pkgPath {
+				if info, ok := parser.FindStruct(pkg.PkgPath, name); ok {
+					return info, true
+				}
 			}
 		}
+	}
+	return parser.FindStruct(pkgPath, name)
+}
 
-		ti, ok := targetFields[target]
+// collectParams returns the extra params of the map functions, a param is skipped if its
+// name is used by the generated code or its type cannot be resolved.
+func collectParams(ctx *converterContext, cf StructConfig, vars map[string]string, sourceParamNames []string, logger *slog.Logger) []Symbol {
+	reserved := append([]string{"m", "out", "err", "decorators", "decorate"}, sourceParamNames...)
+
+	var params []Symbol
+	for _, v := range cf.Params {
+		if !token.IsIdentifier(v.Name) || slices.Contains(reserved, v.Name) || isGeneratedVarName(v.Name) {
+			logger.Warn(
+				"\tinvalid param name",
+				slog.String("target_struct_name", cf.TargetStructName),
+				slog.String("param_name", v.Name),
+			)
+			continue
+		}
+
+		typ, ok := resolveParamType(ctx.Parser(), replacePlaceholders(v.Type, vars), vars[Placeholder.CurrentPackage])
 		if !ok {
+			logger.Warn(
+				"\tcould not resolve param type",
+				slog.String("target_struct_name", cf.TargetStructName),
+				slog.String("param_name", v.Name),
+				slog.String("param_type", v.Type),
+			)
 			continue
 		}
-		si, ok := sourceFields[source]
+		params = append(params, newSymbolWithMetadata(v.Name, "", typ, SymbolMetadata{IsVariable: true}))
+	}
+	return params
+}
+
+// isGeneratedVarName reports whether name has the form of the names returned by
+// ConverterContext.NextVarName, e.g. v0.
+func isGeneratedVarName(name string) bool {
+	if len(name) < 2 || name[0] != 'v' {
+		return false
+	}
+	for _, c := range name[1:] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// resolveParamType resolves a type such as string, *time.Time or []github.com/acme/app.ID,
+// an unqualified type which is not predeclared is looked up in currentPkgPath.
+func resolveParamType(parser Parser, input string, currentPkgPath string) (types.Type, bool) {
+	switch {
+	case strings.HasPrefix(input, "*"):
+		elem, ok := resolveParamType(parser, input[1:], currentPkgPath)
 		if !ok {
+			return nil, false
+		}
+		return types.NewPointer(elem), true
+
+	case strings.HasPrefix(input, "[]"):
+		elem, ok := resolveParamType(parser, input[2:], currentPkgPath)
+		if !ok {
+			return nil, false
+		}
+		return types.NewSlice(elem), true
+	}
+
+	cf := parseConverterFunctionConfigFromString(input)
+	if cf.PackagePath == "" {
+		if tn, ok := types.Universe.Lookup(cf.TypeName).(*types.TypeName); ok {
+			return tn.Type(), true
+		}
+		cf.PackagePath = currentPkgPath
+	}
+
+	ep, ok := parser.(ExtendedParser)
+	if !ok {
+		return nil, false
+	}
+	return ep.FindType(cf.PackagePath, cf.TypeName)
+}
+
+func usePointers(pointer Pointer) (useTargetPointer bool, useSourcePointer bool) {
+	switch pointer {
+	case PointerSourceOnly:
+		return false, true
+	case PointerTargetOnly:
+		return true, false
+	case PointerBoth:
+		return true, true
+	default:
+		return false, false
+	}
+}
+
+func fillMapFunc(
+	ctx *converterContext,
+	mapFunc *genMapFunc,
+	config FieldConfig,
+	useGetter bool,
+	interceptors map[string]FieldInterceptor,
+) {
+	ctx.setJenFile(mapFunc.file)
+	ctx.setReturnError(mapFunc.returnError, mapFunc.zeroResult())
+	ctx.setParams(mapFunc.params)
+
+	targetFields := mapFunc.targetStruct.Fields
+	mappedFields := mapSourceFieldRefs(targetFields, mapFunc.sources, mapFunc.params, config, mapFunc.targetPkgPath, mapFunc.exportedFieldsOnly)
+	mapFunc.matchedFields = make(map[string]string)
+	for target, ref := range mappedFields {
+		if ref.name == "" {
+			mapFunc.missingFields = append(mapFunc.missingFields, target)
 			continue
 		}
-		targetDescriptor := Descriptor{structInfo: mapFunc.targetStruct, structFieldInfo: &ti}
-		sourceDescriptor := Descriptor{structInfo: mapFunc.sourceStruct, structFieldInfo: &si}
+		mapFunc.matchedFields[target] = ref.name
 
-		converter, ok := findConverter(targetDescriptor, sourceDescriptor, ctx.Logger())
+		ti, ok := targetFields[target]
 		if !ok {
-			mapFunc.unconvertibleFields = append(mapFunc.unconvertibleFields, target)
 			continue
 		}
 
-		sourceSymbol := newSymbol("in", source, si.Type)
-		if useGetter && si.Getter != nil {
-			sourceSymbol = sourceSymbol.toGetterSymbol(*si.Getter)
+		var si StructFieldInfo
+		var sourceDescriptor Descriptor
+		var sourceSymbol Symbol
+		if ref.param != nil {
+			// a param is described as a field without a struct
+			si = StructFieldInfo{Name: ref.name, Type: ref.param.Type, IsExported: true}
+			sourceDescriptor = Descriptor{structFieldInfo: &si}
+			sourceSymbol = *ref.param
+		} else {
+			si, ok = ref.source.structInfo.Fields[ref.name]
+			if !ok {
+				continue
+			}
+			sourceDescriptor = Descriptor{structInfo: ref.source.structInfo, structFieldInfo: &si}
+			sourceSymbol = newSymbol(ref.source.paramName, ref.name, si.Type)
+			if useGetter && si.Getter != nil {
+				sourceSymbol = sourceSymbol.toGetterSymbol(*si.Getter)
+			}
 		}
+		targetDescriptor := Descriptor{structInfo: mapFunc.targetStruct, structFieldInfo: &ti}
 
 		var interceptor FieldInterceptor
 		if interceptors != nil {
@@ -587,11 +945,30 @@ func fillMapFunc(
 			}
 		}
 
+		scope := ctx.lookupContext.scope
+		var converter Converter
+		if interceptor != nil {
+			converter, ok = findInterceptedConverter(scope, targetDescriptor, sourceDescriptor, mapFunc.returnError, mapFunc.params, interceptor, ctx.Logger())
+		} else {
+			converter, ok = findConverter(scope, targetDescriptor, sourceDescriptor, mapFunc.returnError, mapFunc.params, ctx.Logger())
+		}
+		if !ok {
+			// the interceptor may convert the field without a converter, e.g. use-function
+			if provider, isProvider := interceptor.(FieldConverterProvider); isProvider {
+				lookup := newLookupContext(targetDescriptor, sourceDescriptor, mapFunc.returnError, ctx.Logger()).withScope(scope).withParams(mapFunc.params)
+				converter, ok = provider.FieldConverter(lookup, ti.Type, si.Type)
+			}
+			if !ok {
+				mapFunc.unconvertibleFields = append(mapFunc.unconvertibleFields, target)
+				continue
+			}
+		}
+
 		field := convertibleField{
 			index:            ti.Index,
 			targetFieldName:  target,
 			targetSymbol:     newSymbolWithMetadata("out", target, ti.Type, SymbolMetadata{HasZeroValue: true}),
-			sourceFieldName:  source,
+			sourceFieldName:  ref.name,
 			sourceSymbol:     sourceSymbol,
 			converter:        converter,
 			targetDescriptor: targetDescriptor,
@@ -615,6 +992,80 @@ func fillMapFunc(
 	})
 }
 
+// mapSourceFieldRefs returns the source field of each target field. A field is matched in
+// the sources in order, the first source wins, a manually mapped field can be qualified by
+// the param name of a source, e.g. profile.Bio, or refer to a param, e.g. $tenantID. Only
+// exported fields are mapped if exportedOnly is true.
+func mapSourceFieldRefs(targetFields map[string]StructFieldInfo, sources []genMapSource, params []Symbol, config FieldConfig, targetPkgPath string, exportedOnly bool) map[string]sourceFieldRef {
+	var result map[string]sourceFieldRef
+	if len(sources) == 1 {
+		result = make(map[string]sourceFieldRef)
+		samePkg := !exportedOnly && targetPkgPath == sources[0].pkgPath
+		for target, source := range mapFieldNames(targetFields, sources[0].structInfo.Fields, config, samePkg) {
+			result[target] = sourceFieldRef{source: &sources[0], name: source}
+		}
+	} else {
+		result = mapMultiSourceFieldRefs(targetFields, sources, config, targetPkgPath, exportedOnly)
+	}
+
+	for target, manualSource := range config.ManualMap {
+		name, ok := strings.CutPrefix(manualSource, ParamReferencePrefix)
+		if _, have := result[target]; !ok || !have {
+			continue
+		}
+
+		result[target] = sourceFieldRef{}
+		if param, found := findParam(params, name); found {
+			result[target] = sourceFieldRef{name: manualSource, param: &param}
+		}
+	}
+	return result
+}
+
+func mapMultiSourceFieldRefs(targetFields map[string]StructFieldInfo, sources []genMapSource, config FieldConfig, targetPkgPath string, exportedOnly bool) map[string]sourceFieldRef {
+
+	result := make(map[string]sourceFieldRef)
+	for i, source := range sources {
+		samePkg := !exportedOnly && targetPkgPath == source.pkgPath
+		names := mapFieldNames(targetFields, source.structInfo.Fields, FieldConfig{NameMatch: config.NameMatch}, samePkg)
+		for target, name := range names {
+			if ref, ok := result[target]; ok && ref.name != "" {
+				continue
+			}
+			result[target] = sourceFieldRef{source: &sources[i], name: name}
+		}
+	}
+
+	for target, manualSource := range config.ManualMap {
+		if _, ok := result[target]; !ok {
+			continue
+		}
+
+		result[target] = sourceFieldRef{}
+		if strings.HasPrefix(manualSource, ParamReferencePrefix) {
+			continue
+		}
+
+		param, field, qualified := strings.Cut(manualSource, ".")
+		for i, source := range sources {
+			name := manualSource
+			if qualified {
+				if param != source.paramName {
+					continue
+				}
+				name = field
+			}
+
+			info, have := source.structInfo.Fields[name]
+			if have && ((!exportedOnly && targetPkgPath == source.pkgPath) || info.IsExported) {
+				result[target] = sourceFieldRef{source: &sources[i], name: name}
+				break
+			}
+		}
+	}
+	return result
+}
+
 func mapFieldNames(targetFields, sourceFields map[string]StructFieldInfo, config FieldConfig, samePkg bool) map[string]string {
 	result := make(map[string]string)
 	for target, targetInfo := range targetFields {
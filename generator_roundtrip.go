@@ -0,0 +1,508 @@
+package gomappergen
+
+import (
+	"fmt"
+	"go/types"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/toniphan21/go-mapper-gen/internal/util"
+	"golang.org/x/tools/go/packages"
+)
+
+// roundTripField is a source field which is mapped to a target field by the source-to-target
+// function and back by the target-to-source function.
+type roundTripField struct {
+	source StructFieldInfo
+	target StructFieldInfo
+}
+
+// roundTripPair is the source-to-target and target-to-source functions of a struct config.
+type roundTripPair struct {
+	toTarget   *genMapFunc
+	fromTarget *genMapFunc
+}
+
+// findRoundTripPairs returns the map functions which are generated in both directions.
+func findRoundTripPairs(mapFuncs []*genMapFunc) []roundTripPair {
+	var pairs []roundTripPair
+	for _, to := range mapFuncs {
+		mapperName, ok := strings.CutSuffix(to.name, "-SourceToTarget")
+		if !ok {
+			continue
+		}
+
+		for _, from := range mapFuncs {
+			if from.name == mapperName+"-TargetToSource" {
+				pairs = append(pairs, roundTripPair{toTarget: to, fromTarget: from})
+				break
+			}
+		}
+	}
+	return pairs
+}
+
+// fields returns the fields which are mapped both ways, ordered by the source field index.
+// A field which is missing or unconvertible in any direction is skipped, so is a field which
+// uses a field interceptor because a custom function is not guaranteed to be reversible, and
+// a field which lossReason reports because it may not be equal after the round-trip.
+func (p roundTripPair) fields() []roundTripField {
+	from := make(map[string]convertibleField)
+	for _, field := range p.fromTarget.mappedFields {
+		if !slices.Contains(p.fromTarget.unconvertibleFields, field.targetFieldName) {
+			from[field.targetFieldName] = field
+		}
+	}
+
+	var result []roundTripField
+	for _, field := range p.toTarget.mappedFields {
+		if field.interceptor != nil || slices.Contains(p.toTarget.unconvertibleFields, field.targetFieldName) {
+			continue
+		}
+
+		back, ok := from[field.sourceFieldName]
+		if !ok || back.interceptor != nil || back.sourceFieldName != field.targetFieldName {
+			continue
+		}
+
+		if p.lossReason(*back.targetDescriptor.structFieldInfo) != "" {
+			continue
+		}
+
+		result = append(result, roundTripField{
+			source: *back.targetDescriptor.structFieldInfo,
+			target: *field.targetDescriptor.structFieldInfo,
+		})
+	}
+
+	slices.SortFunc(result, func(a, b roundTripField) int {
+		return a.source.Index - b.source.Index
+	})
+	return result
+}
+
+// roundTripLoss is a source field which may not be equal after mapping to the target and
+// back, reason describes why.
+type roundTripLoss struct {
+	field  string
+	reason string
+}
+
+// roundTripReport lists the lossy fields of FromTarget(ToTarget(x)), it is attached to the
+// source-to-target function to be rendered in its GoDoc comment.
+type roundTripReport struct {
+	toTarget   string
+	fromTarget string
+	losses     []roundTripLoss
+}
+
+// losses returns the source fields which may not be equal after mapping to the target and
+// back, ordered by the source field index.
+func (p roundTripPair) losses() []roundTripLoss {
+	var sourceFields []StructFieldInfo
+	for _, field := range p.toTarget.sources[0].structInfo.Fields {
+		sourceFields = append(sourceFields, field)
+	}
+	slices.SortFunc(sourceFields, func(a, b StructFieldInfo) int {
+		return a.Index - b.Index
+	})
+
+	var result []roundTripLoss
+	for _, source := range sourceFields {
+		if reason := p.lossReason(source); reason != "" {
+			result = append(result, roundTripLoss{field: source.Name, reason: reason})
+		}
+	}
+	return result
+}
+
+// lossReason returns the reason why the source field may be lossy, or an empty string if
+// the field is equal after mapping to the target and back.
+func (p roundTripPair) lossReason(source StructFieldInfo) string {
+	to, from := p.toTarget, p.fromTarget
+
+	targetName, ok := to.matchedTargetField(source.Name)
+	if !ok {
+		return "not mapped to the target"
+	}
+
+	idx := slices.IndexFunc(to.mappedFields, func(f convertibleField) bool {
+		return f.targetFieldName == targetName
+	})
+	if idx == -1 || slices.Contains(to.unconvertibleFields, targetName) {
+		return fmt.Sprintf("cannot be converted to %s", targetName)
+	}
+
+	forth := to.mappedFields[idx]
+	target := *forth.targetDescriptor.structFieldInfo
+
+	idx = slices.IndexFunc(from.mappedFields, func(f convertibleField) bool {
+		return f.targetFieldName == source.Name && f.sourceFieldName == target.Name
+	})
+	if idx == -1 || slices.Contains(from.unconvertibleFields, source.Name) {
+		return fmt.Sprintf("mapped one way only, %s is not mapped back", target.Name)
+	}
+	back := from.mappedFields[idx]
+
+	// nil-if-zero is lossless from a value to a pointer and back, but not from a pointer
+	// to the zero value
+	if back.interceptor != nil && back.interceptor.GetType() == nilIfZeroType {
+		return "nil-if-zero, a pointer to a zero value becomes nil"
+	}
+
+	for _, interceptor := range []FieldInterceptor{forth.interceptor, back.interceptor} {
+		if interceptor != nil && interceptor.GetType() != nilIfZeroType {
+			return fmt.Sprintf("uses the %s field interceptor", interceptor.GetType())
+		}
+	}
+
+	if _, ok := source.Type.(*types.Pointer); ok {
+		if _, ok := target.Type.(*types.Pointer); !ok {
+			return "pointer to value, nil becomes a pointer to a zero value"
+		}
+	}
+
+	if isNumeric(source.Type) && isNumeric(target.Type) {
+		if _, _, narrowing := (&numericConverter{}).narrowingBounds(source.Type, target.Type); narrowing {
+			return fmt.Sprintf("narrowed numeric conversion %s -> %s", source.Type.String(), target.Type.String())
+		}
+	}
+
+	if c, ok := forth.converter.(RoundTripLossReporter); ok {
+		return c.RoundTripLoss(target.Type, source.Type)
+	}
+
+	// a converter of another package, e.g. converters/pgtype, is not analyzed
+	for _, c := range []Converter{forth.converter, back.converter} {
+		if c != nil && !isRootPackageConverter(c) {
+			return fmt.Sprintf("converted by %s, the round-trip is unknown", c.Info().Name)
+		}
+	}
+	return ""
+}
+
+func isRootPackageConverter(c Converter) bool {
+	t := reflect.TypeOf(c)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.PkgPath() == reflect.TypeOf(roundTripPair{}).PkgPath()
+}
+
+// comment returns the lines of the round-trip report in the GoDoc comment.
+func (r *roundTripReport) comment() []string {
+	call := fmt.Sprintf("%s(%s(x))", r.fromTarget, r.toTarget)
+	if len(r.losses) == 0 {
+		return []string{call + " is equal to x."}
+	}
+
+	lines := []string{call + " may not be equal to x:"}
+	for _, loss := range r.losses {
+		lines = append(lines, fmt.Sprintf("  - %s: %s", loss.field, loss.reason))
+	}
+	return lines
+}
+
+// reportRoundTrips logs the round-trip report of the map functions which are generated in
+// both directions, the report is attached to the source-to-target function if enabled.
+func reportRoundTrips(config PackageConfig, mapFuncs []*genMapFunc, logger *slog.Logger) {
+	for _, pair := range findRoundTripPairs(mapFuncs) {
+		report := &roundTripReport{
+			toTarget:   pair.toTarget.funcName,
+			fromTarget: pair.fromTarget.funcName,
+			losses:     pair.losses(),
+		}
+
+		lines := report.comment()
+		logger.Info(fmt.Sprintf("\tround-trip %s", lines[0]))
+		for _, line := range lines[1:] {
+			logger.Info("\t\t" + strings.TrimSpace(line))
+		}
+
+		if config.GenerateRoundTripReport {
+			pair.toTarget.roundTripReport = report
+		}
+	}
+}
+
+func isNumeric(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsNumeric != 0 && basic.Info()&types.IsComplex == 0
+}
+
+// generateRoundTripTests generates a test for each pair of map functions, testFile is called
+// when the first test is generated so the file is not made if there is no test.
+func generateRoundTripTests(testFile func() *jen.File, outputPkg *packages.Package, config PackageConfig, mapFuncs []*genMapFunc, logger *slog.Logger) {
+	for _, pair := range findRoundTripPairs(mapFuncs) {
+		to, from := pair.toTarget, pair.fromTarget
+
+		testName := "Test" + upperFirst(to.funcName) + "_" + upperFirst(from.funcName)
+		if config.Mode == ModeTypes {
+			testName = "Test" + upperFirst(config.InterfaceName) + "_" + upperFirst(to.funcName) + "_" + upperFirst(from.funcName)
+		}
+
+		body, reason := makeRoundTripTestBody(outputPkg, config, mapFuncs, pair)
+		if reason != "" {
+			logger.Warn(util.ColorYellow(fmt.Sprintf("\tcannot generate test %s, %s", testName, reason)))
+			continue
+		}
+
+		testFile().Func().Id(testName).Params(jen.Id("t").Op("*").Qual("testing", "T")).Block(body...).Line()
+		logger.Info(fmt.Sprintf("\tgenerated test %s", util.ColorBlue(testName)))
+	}
+}
+
+// makeRoundTripTestBody returns the body of the round-trip test, or the reason why the test
+// cannot be generated.
+func makeRoundTripTestBody(outputPkg *packages.Package, config PackageConfig, mapFuncs []*genMapFunc, pair roundTripPair) ([]jen.Code, string) {
+	/** generated code:
+	m := new_iMapper(nil)
+
+	var in Source
+	in.ID = 1
+	in.Name = "Name"
+
+	target := m.ToTarget(in)
+	out := m.FromTarget(target)
+
+	if !reflect.DeepEqual(in.ID, out.ID) {
+		t.Errorf("ID is not equal after round-trip, expected %v, got %v", in.ID, out.ID)
+	}
+	*/
+	to, from := pair.toTarget, pair.fromTarget
+
+	var body []jen.Code
+	toCall, fromCall := jen.Id(to.funcName), jen.Id(from.funcName)
+	if config.Mode == ModeTypes {
+		var args []jen.Code
+		if shouldUseDecorator(mapFuncs, config) {
+			args = append(args, jen.Nil())
+		}
+		body = append(body, jen.Id("m").Op(":=").Id(config.ConstructorName).Call(args...).Line())
+		toCall, fromCall = jen.Id("m").Dot(to.funcName), jen.Id("m").Dot(from.funcName)
+	}
+
+	var params []jen.Code
+	for _, param := range to.params {
+		if slices.Contains([]string{"t", "in", "target", "out", "err", "m"}, param.VarName) {
+			return nil, "a param name is used by the test"
+		}
+		body = append(body, jen.Var().Id(param.VarName).Add(GeneratorUtil.TypeToJenCode(param.Type)))
+		params = append(params, jen.Id(param.VarName))
+	}
+
+	sourceStruct := to.sources[0].structInfo
+	body = append(body, jen.Var().Id("in").Add(GeneratorUtil.TypeToJenCode(sourceStruct.Type)))
+
+	var varCount int
+	var fields []roundTripField
+	for _, field := range pair.fields() {
+		if !field.source.IsExported && !isStructOfPkg(sourceStruct.Type, outputPkg.PkgPath) {
+			continue
+		}
+
+		pre, value, ok := roundTripValue(field.source.Type, field.target.Type, field.source.Name, &varCount)
+		if !ok {
+			continue
+		}
+		body = append(body, pre...)
+		body = append(body, jen.Id("in").Dot(field.source.Name).Op("=").Add(value))
+		fields = append(fields, field)
+	}
+
+	if len(fields) == 0 {
+		return nil, "no field can be asserted after the round-trip"
+	}
+	body = append(body, jen.Line())
+
+	in := jen.Id("in")
+	if to.sourcePointer {
+		in = jen.Op("&").Id("in")
+	}
+
+	body = append(body, roundTripCall(to, "target", toCall.Call(append([]jen.Code{in}, params...)...))...)
+	body = append(body, roundTripCall(from, "out", fromCall.Call(append([]jen.Code{jen.Id("target")}, params...)...))...)
+	body = append(body, jen.Line())
+
+	for _, field := range fields {
+		name := field.source.Name
+		message := fmt.Sprintf("%s is not equal after round-trip, expected %%v, got %%v", name)
+		body = append(body, jen.If(roundTripNotEqual(field.source.Type, name)).Block(
+			jen.Id("t").Dot("Errorf").Call(jen.Lit(message), jen.Id("in").Dot(name), jen.Id("out").Dot(name)),
+		))
+	}
+	return body, ""
+}
+
+// roundTripCall returns the statements which call a map function and assign the result to
+// varName, the test fails immediately if the map function returns an error.
+func roundTripCall(mf *genMapFunc, varName string, call jen.Code) []jen.Code {
+	if !mf.returnError {
+		return []jen.Code{jen.Id(varName).Op(":=").Add(call)}
+	}
+
+	return []jen.Code{
+		jen.List(jen.Id(varName), jen.Err()).Op(":=").Add(call),
+		jen.If(jen.Err().Op("!=").Nil()).Block(
+			jen.Id("t").Dot("Fatalf").Call(jen.Lit(mf.funcName+" returns an error: %v"), jen.Err()),
+		),
+	}
+}
+
+// roundTripNotEqual returns the condition which reports whether the field of in and out are
+// not equal, the Equal method is used if the type has one, e.g. time.Time.
+func roundTripNotEqual(t types.Type, name string) jen.Code {
+	if hasEqualMethod(t) {
+		return jen.Op("!").Id("in").Dot(name).Dot("Equal").Call(jen.Id("out").Dot(name))
+	}
+	return jen.Op("!").Qual("reflect", "DeepEqual").Call(jen.Id("in").Dot(name), jen.Id("out").Dot(name))
+}
+
+func hasEqualMethod(t types.Type) bool {
+	methodSet := types.NewMethodSet(t)
+	for i := 0; i < methodSet.Len(); i++ {
+		fn, ok := methodSet.At(i).Obj().(*types.Func)
+		if !ok || fn.Name() != "Equal" {
+			continue
+		}
+
+		sig := fn.Type().(*types.Signature)
+		if sig.Params().Len() != 1 || sig.Results().Len() != 1 {
+			return false
+		}
+		return types.Identical(sig.Params().At(0).Type(), t) && types.Identical(sig.Results().At(0).Type(), types.Typ[types.Bool])
+	}
+	return false
+}
+
+// roundTripValue returns a non-zero value of type t, pre are the statements which declare
+// the variables used by the value, e.g. the pointer of a string. The value must survive the
+// conversion to the counterpart and back, see roundTripBasicValue. It returns false if there
+// is no way to make such a value of t.
+func roundTripValue(t, counterpart types.Type, name string, varCount *int) ([]jen.Code, jen.Code, bool) {
+	if TypeUtil.MatchNamedType(t, "time", "Time") {
+		return nil, jen.Qual("time", "Date").Call(
+			jen.Lit(2001), jen.Qual("time", "February"), jen.Lit(3), jen.Lit(4), jen.Lit(5), jen.Lit(6), jen.Lit(0), jen.Qual("time", "UTC"),
+		), true
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		value, ok := roundTripBasicValue(u, counterpart, name)
+		return nil, value, ok
+
+	case *types.Pointer:
+		if ptr, ok := counterpart.(*types.Pointer); ok {
+			counterpart = ptr.Elem()
+		}
+
+		pre, value, ok := roundTripValue(u.Elem(), counterpart, name, varCount)
+		if !ok {
+			return nil, nil, false
+		}
+
+		varName := fmt.Sprintf("v%d", *varCount)
+		*varCount++
+		pre = append(pre, jen.Var().Id(varName).Add(GeneratorUtil.TypeToJenCode(u.Elem())).Op("=").Add(value))
+		return pre, jen.Op("&").Id(varName), true
+
+	case *types.Slice, *types.Array:
+		elem := u.(interface{ Elem() types.Type }).Elem()
+		if counterpartElem, ok := counterpart.Underlying().(interface{ Elem() types.Type }); ok {
+			counterpart = counterpartElem.Elem()
+		}
+
+		pre, value, ok := roundTripValue(elem, counterpart, name, varCount)
+		if !ok || len(pre) != 0 {
+			return nil, nil, false
+		}
+		return nil, jen.Add(GeneratorUtil.TypeToJenCode(t)).Values(value), true
+
+	case *types.Map:
+		var counterpartKey, counterpartElem types.Type = u.Key(), u.Elem()
+		if m, ok := counterpart.Underlying().(*types.Map); ok {
+			counterpartKey, counterpartElem = m.Key(), m.Elem()
+		}
+
+		keyPre, key, ok := roundTripValue(u.Key(), counterpartKey, name, varCount)
+		if !ok || len(keyPre) != 0 {
+			return nil, nil, false
+		}
+
+		elemPre, elem, ok := roundTripValue(u.Elem(), counterpartElem, name, varCount)
+		if !ok || len(elemPre) != 0 {
+			return nil, nil, false
+		}
+		return nil, jen.Add(GeneratorUtil.TypeToJenCode(t)).Values(jen.Add(key).Op(":").Add(elem)), true
+	}
+	return nil, nil, false
+}
+
+// roundTripBasicValue returns a value of a basic type which survives the conversion to the
+// counterpart and back. A float is integral if the counterpart is an integer. A string is the
+// field name if the counterpart is a string, or a number or bool if the counterpart is a number
+// or bool so that it can be parsed. It returns false for a complex number, or a string whose
+// counterpart is another type, e.g. uuid.UUID or time.Time, because a valid value depends on
+// the converter.
+func roundTripBasicValue(basic *types.Basic, counterpart types.Type, name string) (jen.Code, bool) {
+	if ptr, ok := counterpart.(*types.Pointer); ok {
+		counterpart = ptr.Elem()
+	}
+
+	cb, _ := counterpart.Underlying().(*types.Basic)
+	switch {
+	case basic.Info()&types.IsComplex != 0:
+		return nil, false
+
+	case basic.Info()&types.IsBoolean != 0:
+		return jen.True(), true
+
+	case basic.Info()&types.IsInteger != 0:
+		return jen.Lit(1), true
+
+	case basic.Info()&types.IsFloat != 0:
+		if cb != nil && cb.Info()&types.IsInteger != 0 {
+			return jen.Lit(2.0), true
+		}
+		return jen.Lit(1.5), true
+
+	case basic.Info()&types.IsString == 0 || cb == nil:
+		return nil, false
+
+	case cb.Info()&types.IsString != 0:
+		return jen.Lit(name), true
+	}
+
+	// a number with methods may be converted by a converter which does not use its value,
+	// e.g. a protobuf enum is converted from/to its name
+	if types.NewMethodSet(types.NewPointer(counterpart)).Len() != 0 {
+		return nil, false
+	}
+
+	switch {
+	case cb.Info()&types.IsBoolean != 0:
+		return jen.Lit("true"), true
+
+	case cb.Info()&types.IsInteger != 0:
+		return jen.Lit("1"), true
+
+	case cb.Info()&types.IsFloat != 0:
+		return jen.Lit("1.5"), true
+	}
+	return nil, false
+}
+
+func isStructOfPkg(t types.Type, pkgPath string) bool {
+	named, ok := types.Unalias(t).(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == pkgPath
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
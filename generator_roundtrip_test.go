@@ -0,0 +1,283 @@
+package gomappergen
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/tools/go/packages"
+)
+
+func Test_roundTripPair_fields(t *testing.T) {
+	field := func(target, source string, interceptor FieldInterceptor) convertibleField {
+		typ := types.Type(types.Typ[types.String])
+		if target == "Count" {
+			typ = types.Typ[types.Int64]
+		}
+
+		return convertibleField{
+			targetFieldName:  target,
+			sourceFieldName:  source,
+			targetDescriptor: Descriptor{structFieldInfo: &StructFieldInfo{Name: target, Type: typ}},
+			interceptor:      interceptor,
+		}
+	}
+	narrowed := field("Count", "Count", nil)
+	narrowed.targetDescriptor.structFieldInfo.Type = types.Typ[types.Int32]
+
+	to := &genMapFunc{
+		name: "User-SourceToTarget",
+		mappedFields: []convertibleField{
+			field("ID", "ID", nil),
+			field("Name", "FullName", nil),
+			field("Email", "Email", nil),
+			field("Age", "Age", nil),
+			field("Note", "Note", BuiltinFieldInterceptor.UseFunction("strings.ToUpper")),
+			narrowed,
+		},
+		unconvertibleFields: []string{"Age"},
+		matchedFields:       map[string]string{"ID": "ID", "Name": "FullName", "Email": "Email", "Age": "Age", "Note": "Note", "Count": "Count"},
+	}
+	from := &genMapFunc{
+		name: "User-TargetToSource",
+		mappedFields: []convertibleField{
+			field("ID", "ID", nil),
+			field("FullName", "Name", nil),
+			field("Email", "Email", nil),
+			field("Age", "Age", nil),
+			field("Note", "Note", nil),
+			field("Count", "Count", nil),
+		},
+		unconvertibleFields: []string{"Email"},
+	}
+
+	pairs := findRoundTripPairs([]*genMapFunc{from, to})
+	assert.Len(t, pairs, 1)
+
+	var names []string
+	for _, f := range pairs[0].fields() {
+		names = append(names, f.source.Name+"<->"+f.target.Name)
+	}
+	assert.Equal(t, []string{"ID<->ID", "FullName<->Name"}, names)
+}
+
+func Test_roundTripValue(t *testing.T) {
+	str := types.Typ[types.String]
+	cases := []struct {
+		name        string
+		typ         types.Type
+		counterpart types.Type
+		expected    string
+		expectedOk  bool
+	}{
+		{name: "string", typ: str, counterpart: str, expected: `"Field"`, expectedOk: true},
+		{name: "string to int", typ: str, counterpart: types.Typ[types.Int], expected: `"1"`, expectedOk: true},
+		{name: "string to bool", typ: str, counterpart: types.Typ[types.Bool], expected: `"true"`, expectedOk: true},
+		{name: "int", typ: types.Typ[types.Int], counterpart: str, expected: `1`, expectedOk: true},
+		{name: "float", typ: types.Typ[types.Float64], counterpart: str, expected: `1.5`, expectedOk: true},
+		{name: "float to int", typ: types.Typ[types.Float64], counterpart: types.Typ[types.Int], expected: `2.0`, expectedOk: true},
+		{name: "string to named string", typ: str, counterpart: namedType("example.com/user", "Email", str), expected: `"Field"`, expectedOk: true},
+		{name: "string to uuid", typ: str, counterpart: namedType("github.com/google/uuid", "UUID", types.NewArray(types.Typ[types.Byte], 16))},
+		{name: "string to struct", typ: str, counterpart: namedType("github.com/jackc/pgx/v5/pgtype", "Numeric", types.NewStruct(nil, nil))},
+		{name: "string to enum", typ: str, counterpart: enumType()},
+		{name: "string to time", typ: str, counterpart: namedType("time", "Time", types.NewStruct(nil, nil))},
+		{name: "pointer to time", typ: types.NewPointer(str), counterpart: types.NewPointer(namedType("time", "Time", types.NewStruct(nil, nil)))},
+		{name: "pointer", typ: types.NewPointer(str), counterpart: str, expected: "var v0 string = \"Field\"\n&v0", expectedOk: true},
+		{name: "slice", typ: types.NewSlice(str), counterpart: types.NewSlice(str), expected: `[]string{"Field"}`, expectedOk: true},
+		{name: "map", typ: types.NewMap(str, types.Typ[types.Int]), counterpart: str, expected: `map[string]int{"Field": 1}`, expectedOk: true},
+		{name: "complex", typ: types.Typ[types.Complex64], counterpart: str},
+		{name: "struct", typ: types.NewStruct(nil, nil), counterpart: str},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var varCount int
+			pre, value, ok := roundTripValue(tc.typ, tc.counterpart, "Field", &varCount)
+
+			assert.Equal(t, tc.expectedOk, ok)
+			if !ok {
+				return
+			}
+
+			code := jen.Add(value)
+			if len(pre) > 0 {
+				code = jen.Add(pre...).Line().Add(value)
+			}
+			assert.Equal(t, tc.expected, fmt.Sprintf("%#v", code))
+		})
+	}
+}
+
+func Test_roundTripPair_losses(t *testing.T) {
+	str := types.Typ[types.String]
+	sourceFields := map[string]StructFieldInfo{
+		"ID":      {Name: "ID", Index: 0, Type: types.Typ[types.Int64]},
+		"Name":    {Name: "Name", Index: 1, Type: str},
+		"Phone":   {Name: "Phone", Index: 2, Type: types.NewPointer(str)},
+		"Version": {Name: "Version", Index: 3, Type: types.Typ[types.Int64]},
+		"Status":  {Name: "Status", Index: 4, Type: str},
+		"Email":   {Name: "Email", Index: 5, Type: str},
+		"Other":   {Name: "Other", Index: 6, Type: str},
+	}
+	targetFields := map[string]StructFieldInfo{
+		"ID":      {Name: "ID", Index: 0, Type: types.Typ[types.Int64]},
+		"Name":    {Name: "Name", Index: 1, Type: str},
+		"Phone":   {Name: "Phone", Index: 2, Type: str},
+		"Version": {Name: "Version", Index: 3, Type: types.Typ[types.Int32]},
+		"Status":  {Name: "Status", Index: 4, Type: types.Typ[types.Int]},
+		"Email":   {Name: "Email", Index: 5, Type: str},
+	}
+
+	field := func(fields map[string]StructFieldInfo, target, source string, interceptor FieldInterceptor) convertibleField {
+		info := fields[target]
+		return convertibleField{
+			targetFieldName:  target,
+			sourceFieldName:  source,
+			targetDescriptor: Descriptor{structFieldInfo: &info},
+			interceptor:      interceptor,
+		}
+	}
+
+	to := &genMapFunc{
+		name:    "User-SourceToTarget",
+		sources: []genMapSource{{structInfo: &StructInfo{Fields: sourceFields}}},
+		mappedFields: []convertibleField{
+			field(targetFields, "ID", "ID", nil),
+			field(targetFields, "Name", "Name", nil),
+			field(targetFields, "Phone", "Phone", nil),
+			field(targetFields, "Version", "Version", nil),
+			field(targetFields, "Email", "Email", nil),
+		},
+		unconvertibleFields: []string{"Status"},
+		matchedFields:       map[string]string{"ID": "ID", "Name": "Name", "Phone": "Phone", "Version": "Version", "Status": "Status", "Email": "Email"},
+	}
+	from := &genMapFunc{
+		name: "User-TargetToSource",
+		mappedFields: []convertibleField{
+			field(sourceFields, "ID", "ID", nil),
+			field(sourceFields, "Name", "Name", BuiltinFieldInterceptor.UseFunction("strings.ToUpper")),
+			field(sourceFields, "Phone", "Phone", nil),
+			field(sourceFields, "Version", "Version", nil),
+		},
+	}
+
+	pairs := findRoundTripPairs([]*genMapFunc{to, from})
+	assert.Len(t, pairs, 1)
+
+	var actual []string
+	for _, loss := range pairs[0].losses() {
+		actual = append(actual, loss.field+": "+loss.reason)
+	}
+	assert.Equal(t, []string{
+		"Name: uses the use-function field interceptor",
+		"Phone: pointer to value, nil becomes a pointer to a zero value",
+		"Version: narrowed numeric conversion int64 -> int32",
+		"Status: cannot be converted to Status",
+		"Email: mapped one way only, Email is not mapped back",
+		"Other: not mapped to the target",
+	}, actual)
+}
+
+func namedType(pkgPath, name string, underlying types.Type) types.Type {
+	pkg := types.NewPackage(pkgPath, pkgPath[strings.LastIndex(pkgPath, "/")+1:])
+	return types.NewNamed(types.NewTypeName(0, pkg, name, nil), underlying, nil)
+}
+
+func Test_roundTripPair_losses_converters(t *testing.T) {
+	str, i64 := types.Typ[types.String], types.Typ[types.Int64]
+	timeType := MakeTypeInfo(time.Time{}).ToType()
+	sourceFields := map[string]StructFieldInfo{
+		"CreatedAt": {Name: "CreatedAt", Index: 0, Type: timeType},
+		"UpdatedAt": {Name: "UpdatedAt", Index: 1, Type: timeType},
+		"DeletedAt": {Name: "DeletedAt", Index: 2, Type: str},
+		"ExpiredAt": {Name: "ExpiredAt", Index: 3, Type: i64},
+		"Count":     {Name: "Count", Index: 4, Type: str},
+		"Score":     {Name: "Score", Index: 5, Type: i64},
+		"Amount":    {Name: "Amount", Index: 6, Type: str},
+	}
+	targetFields := map[string]StructFieldInfo{
+		"CreatedAt": {Name: "CreatedAt", Index: 0, Type: i64},
+		"UpdatedAt": {Name: "UpdatedAt", Index: 1, Type: str},
+		"DeletedAt": {Name: "DeletedAt", Index: 2, Type: timeType},
+		"ExpiredAt": {Name: "ExpiredAt", Index: 3, Type: timeType},
+		"Count":     {Name: "Count", Index: 4, Type: i64},
+		"Score":     {Name: "Score", Index: 5, Type: str},
+		"Amount":    {Name: "Amount", Index: 6, Type: str},
+	}
+
+	timeConv := &timeConverter{layout: time.DateOnly}
+	strconvConv := &strconvConverter{parseMode: ParseModeZero}
+	converters := map[string]Converter{
+		"CreatedAt": timeConv, "UpdatedAt": timeConv, "DeletedAt": timeConv, "ExpiredAt": timeConv,
+		"Count": strconvConv, "Score": strconvConv, "Amount": &lossReporterConverter{},
+	}
+
+	var to, from []convertibleField
+	matched := make(map[string]string)
+	for name := range sourceFields {
+		targetInfo, sourceInfo := targetFields[name], sourceFields[name]
+		to = append(to, convertibleField{targetFieldName: name, sourceFieldName: name, targetDescriptor: Descriptor{structFieldInfo: &targetInfo}, converter: converters[name]})
+		from = append(from, convertibleField{targetFieldName: name, sourceFieldName: name, targetDescriptor: Descriptor{structFieldInfo: &sourceInfo}, converter: converters[name]})
+		matched[name] = name
+	}
+
+	pairs := findRoundTripPairs([]*genMapFunc{
+		{name: "Event-SourceToTarget", sources: []genMapSource{{structInfo: &StructInfo{Fields: sourceFields}}}, mappedFields: to, matchedFields: matched},
+		{name: "Event-TargetToSource", mappedFields: from},
+	})
+	assert.Len(t, pairs, 1)
+
+	var actual []string
+	for _, loss := range pairs[0].losses() {
+		actual = append(actual, loss.field+": "+loss.reason)
+	}
+	assert.Equal(t, []string{
+		"CreatedAt: Unix timestamp in seconds, the precision below a second and the location are lost",
+		`UpdatedAt: formatted with the layout "2006-01-02", the parts of the time which the layout does not include are lost`,
+		`DeletedAt: parsed with the layout "2006-01-02", a string which is not formatted exactly by the layout is changed`,
+		"Count: parsed as int64 by strconv, a string which is not in the canonical format is changed, e.g. 01 becomes 1",
+		"Amount: rounded to 2 decimal places",
+	}, actual)
+
+	var names []string
+	for _, f := range pairs[0].fields() {
+		names = append(names, f.source.Name)
+	}
+	assert.Equal(t, []string{"ExpiredAt", "Score"}, names)
+}
+
+func enumType() types.Type {
+	named := namedType("example.com/pb", "Status", types.Typ[types.Int32]).(*types.Named)
+	sig := types.NewSignatureType(types.NewVar(0, nil, "x", named), nil, nil, nil, types.NewTuple(types.NewVar(0, nil, "", types.Typ[types.String])), false)
+	named.AddMethod(types.NewFunc(0, named.Obj().Pkg(), "String", sig))
+	return named
+}
+
+func Test_makeRoundTripTestBody_noField(t *testing.T) {
+	source := namedType("example.com/user", "UserEntity", types.NewStruct(nil, nil))
+	to := &genMapFunc{
+		name:     "User-SourceToTarget",
+		funcName: "ToUser",
+		sources:  []genMapSource{{structInfo: &StructInfo{Type: source, Fields: map[string]StructFieldInfo{}}}},
+	}
+	from := &genMapFunc{name: "User-TargetToSource", funcName: "FromUser"}
+
+	pairs := findRoundTripPairs([]*genMapFunc{to, from})
+	assert.Len(t, pairs, 1)
+
+	body, reason := makeRoundTripTestBody(&packages.Package{PkgPath: "example.com/user"}, PackageConfig{Mode: ModeFunctions}, []*genMapFunc{to, from}, pairs[0])
+	assert.Nil(t, body)
+	assert.Equal(t, "no field can be asserted after the round-trip", reason)
+}
+
+type lossReporterConverter struct {
+	dummyConverter
+}
+
+func (c *lossReporterConverter) RoundTripLoss(_, _ types.Type) string {
+	return "rounded to 2 decimal places"
+}
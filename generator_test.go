@@ -1,6 +1,7 @@
 package gomappergen
 
 import (
+	"fmt"
 	"go/types"
 	"log/slog"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"github.com/dave/jennifer/jen"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
 )
 
 func Test_mapFieldNames(t *testing.T) {
@@ -194,6 +196,118 @@ func Test_mapFieldNames(t *testing.T) {
 	}
 }
 
+func Test_mapSourceFieldRefs(t *testing.T) {
+	fields := func(names ...string) map[string]StructFieldInfo {
+		result := make(map[string]StructFieldInfo)
+		for i, name := range names {
+			result[name] = StructFieldInfo{Name: name, Index: i, IsExported: true}
+		}
+		return result
+	}
+
+	user := genMapSource{paramName: "user", pkgPath: "db", structInfo: &StructInfo{Fields: fields("ID", "Name", "Email")}}
+	profile := genMapSource{paramName: "profile", pkgPath: "db", structInfo: &StructInfo{Fields: fields("ID", "Name", "Biography", "Avatar")}}
+	params := []Symbol{{VarName: "tenantID", Type: types.Typ[types.String]}}
+
+	cases := []struct {
+		name     string
+		sources  []genMapSource
+		config   FieldConfig
+		expected map[string]string
+	}{
+		{
+			name:     "the first source wins",
+			sources:  []genMapSource{user, profile},
+			config:   FieldConfig{NameMatch: NameMatchIgnoreCase},
+			expected: map[string]string{"ID": "user.ID", "Name": "user.Name", "Email": "user.Email", "Bio": "", "Avatar": "profile.Avatar"},
+		},
+
+		{
+			name:     "precedence follows the order of sources",
+			sources:  []genMapSource{profile, user},
+			config:   FieldConfig{NameMatch: NameMatchIgnoreCase},
+			expected: map[string]string{"ID": "profile.ID", "Name": "profile.Name", "Email": "user.Email", "Bio": "", "Avatar": "profile.Avatar"},
+		},
+
+		{
+			name:     "manual map qualified by param name",
+			sources:  []genMapSource{user, profile},
+			config:   FieldConfig{NameMatch: NameMatchIgnoreCase, ManualMap: map[string]string{"Bio": "profile.Biography", "Name": "profile.Name"}},
+			expected: map[string]string{"ID": "user.ID", "Name": "profile.Name", "Email": "user.Email", "Bio": "profile.Biography", "Avatar": "profile.Avatar"},
+		},
+
+		{
+			name:     "manual map without qualifier is matched in the sources in order",
+			sources:  []genMapSource{user, profile},
+			config:   FieldConfig{NameMatch: NameMatchIgnoreCase, ManualMap: map[string]string{"Bio": "Biography"}},
+			expected: map[string]string{"ID": "user.ID", "Name": "user.Name", "Email": "user.Email", "Bio": "profile.Biography", "Avatar": "profile.Avatar"},
+		},
+
+		{
+			name:     "manual map qualified by unknown param name",
+			sources:  []genMapSource{user, profile},
+			config:   FieldConfig{NameMatch: NameMatchIgnoreCase, ManualMap: map[string]string{"Bio": "account.Biography"}},
+			expected: map[string]string{"ID": "user.ID", "Name": "user.Name", "Email": "user.Email", "Bio": "", "Avatar": "profile.Avatar"},
+		},
+
+		{
+			name:     "manual map to a param in a single source mapping",
+			sources:  []genMapSource{profile},
+			config:   FieldConfig{NameMatch: NameMatchIgnoreCase, ManualMap: map[string]string{"Email": "$tenantID"}},
+			expected: map[string]string{"ID": "profile.ID", "Name": "profile.Name", "Email": "$tenantID", "Bio": "", "Avatar": "profile.Avatar"},
+		},
+
+		{
+			name:     "manual map to a param in a multi-source mapping",
+			sources:  []genMapSource{user, profile},
+			config:   FieldConfig{NameMatch: NameMatchIgnoreCase, ManualMap: map[string]string{"ID": "$tenantID"}},
+			expected: map[string]string{"ID": "$tenantID", "Name": "user.Name", "Email": "user.Email", "Bio": "", "Avatar": "profile.Avatar"},
+		},
+
+		{
+			name:     "manual map to an unknown param",
+			sources:  []genMapSource{user, profile},
+			config:   FieldConfig{NameMatch: NameMatchIgnoreCase, ManualMap: map[string]string{"ID": "$accountID"}},
+			expected: map[string]string{"ID": "", "Name": "user.Name", "Email": "user.Email", "Bio": "", "Avatar": "profile.Avatar"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := mapSourceFieldRefs(fields("ID", "Name", "Email", "Bio", "Avatar"), tc.sources, params, tc.config, "view", false)
+
+			actual := make(map[string]string)
+			for target, ref := range result {
+				actual[target] = ""
+				if ref.param != nil {
+					actual[target] = ref.name
+				} else if ref.name != "" {
+					actual[target] = ref.source.paramName + "." + ref.name
+				}
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func Test_mapSourceFieldRefs_exportedOnly(t *testing.T) {
+	fields := map[string]StructFieldInfo{
+		"ID":     {Name: "ID", Index: 0, IsExported: true},
+		"secret": {Name: "secret", Index: 1},
+	}
+	source := genMapSource{paramName: "in", pkgPath: "db", structInfo: &StructInfo{Fields: fields}}
+
+	for _, exportedOnly := range []bool{false, true} {
+		t.Run(fmt.Sprintf("exportedOnly=%v", exportedOnly), func(t *testing.T) {
+			result := mapSourceFieldRefs(fields, []genMapSource{source}, nil, FieldConfig{NameMatch: NameMatchExact}, "db", exportedOnly)
+
+			_, have := result["secret"]
+			assert.Equal(t, !exportedOnly, have)
+			assert.Equal(t, "ID", result["ID"].name)
+		})
+	}
+}
+
 type dummyConverter struct {
 }
 
@@ -315,3 +429,16 @@ var _ iMapperDecorator = (*iMapperDecoratorNoOp)(nil)
 		RegisterConverter(&dummyConverter{})
 	}))
 }
+
+func TestGenerate_returnsGenerateError(t *testing.T) {
+	g := &generatorImpl{fileManager: DefaultFileManager(), logger: NewNoopLogger()}
+
+	config := PackageConfig{Output: Output{Dir: "internal/mapping"}}
+	err := g.Generate(&packages.Package{PkgPath: "github.com/acme/app/domain"}, []PackageConfig{config})
+
+	var ge *GenerateError
+	require.ErrorAs(t, err, &ge)
+	assert.Equal(t, "github.com/acme/app/domain", ge.PkgPath)
+	assert.Equal(t, "", ge.MapperName)
+	assert.Equal(t, `package github.com/acme/app/domain: output.import_path is required when output.dir "internal/mapping" is set`, err.Error())
+}
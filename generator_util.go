@@ -34,16 +34,28 @@ func (g *genUtil) TypeToJenCode(t types.Type) jen.Code {
 	case *types.Named:
 		obj := tt.Obj()
 		pkg := obj.Pkg()
+
+		var code *jen.Statement
 		if pkg != nil {
-			return jen.Qual(pkg.Path(), obj.Name())
+			code = jen.Qual(pkg.Path(), obj.Name())
+		} else {
+			code = jen.Id(obj.Name())
+		}
+
+		if args := tt.TypeArgs(); args.Len() > 0 {
+			var typeArgs []jen.Code
+			for i := 0; i < args.Len(); i++ {
+				typeArgs = append(typeArgs, g.TypeToJenCode(args.At(i)))
+			}
+			code = code.Types(typeArgs...)
 		}
-		return jen.Id(obj.Name())
+		return code
 
 	case *types.Slice:
 		return jen.Index().Add(g.TypeToJenCode(tt.Elem()))
 
 	case *types.Array:
-		return jen.Index(jen.Lit(tt.Len())).Add(g.TypeToJenCode(tt.Elem()))
+		return jen.Index(jen.Lit(int(tt.Len()))).Add(g.TypeToJenCode(tt.Elem()))
 
 	case *types.Map:
 		return jen.Map(g.TypeToJenCode(tt.Key())).Add(g.TypeToJenCode(tt.Elem()))
@@ -182,4 +194,54 @@ func (g *genUtil) WrapText(s string, width int) []string {
 	return lines
 }
 
+// CanReturnError reports whether the mapper function being generated returns an error, it is
+// false if ctx does not implement CanReturnError of ErrorReturner.
+func (g *genUtil) CanReturnError(ctx LookupContext) bool {
+	r, ok := ctx.(interface{ CanReturnError() bool })
+	return ok && r.CanReturnError()
+}
+
+// ReturnError returns the statement which returns err from the mapper function being
+// generated. It must only be used when CanReturnError returns true, it panics with err if
+// ctx does not implement ErrorReturner.
+func (g *genUtil) ReturnError(ctx ConverterContext, err jen.Code) jen.Code {
+	if r, ok := ctx.(ErrorReturner); ok {
+		return r.ReturnError(err)
+	}
+	return jen.Panic(err)
+}
+
+// parseCode emits the code which calls a function returning (value, error) then assigns
+// the value via assign, the error is handled depends on the given ParseMode.
+func (g *genUtil) parseCode(ctx ConverterContext, mode ParseMode, call jen.Code, assign func(value jen.Code) jen.Code) jen.Code {
+	/** generated code:
+	v0, err := {call}
+	if err != nil {
+		return ..., err // or panic(err) if parse mode is must
+	}
+	{assign v0}
+
+	// or if parse mode is zero
+	if v0, err := {call}; err == nil {
+		{assign v0}
+	}
+	*/
+	varName := ctx.NextVarName()
+	if mode == ParseModeZero {
+		return jen.If(
+			jen.List(jen.Id(varName), jen.Err()).Op(":=").Add(call),
+			jen.Err().Op("==").Nil(),
+		).Block(assign(jen.Id(varName)))
+	}
+
+	onError := g.ReturnError(ctx, jen.Err())
+	if mode == ParseModeMust {
+		onError = jen.Panic(jen.Err())
+	}
+
+	code := jen.List(jen.Id(varName), jen.Err()).Op(":=").Add(call).Line()
+	code = code.If(jen.Err().Op("!=").Nil()).Block(onError).Line()
+	return code.Add(assign(jen.Id(varName)))
+}
+
 var GeneratorUtil = &genUtil{}
@@ -8,6 +8,7 @@ require (
 	github.com/apple/pkl-go v0.12.1
 	github.com/dave/jennifer v1.7.1
 	github.com/jackc/pgx/v5 v5.8.0
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/stretchr/testify v1.11.1
 	golang.org/x/mod v0.31.0
 	golang.org/x/tools v0.40.0
@@ -17,7 +18,6 @@ require (
 	github.com/alexflint/go-scalar v1.2.0 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/kr/text v0.2.0 // indirect
-	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/rogpeppe/go-internal v1.14.1 // indirect
 	github.com/vmihailenco/msgpack/v5 v5.4.1 // indirect
 	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
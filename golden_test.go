@@ -21,15 +21,27 @@ func TestGolden(t *testing.T) {
 		{file: "features/config-multiple-mappers.md"},
 		{file: "features/config-multiple-structs.md"},
 		{file: "features/functions-converter.md"},
+		{file: "features/generate-test.md"},
+		{file: "features/multi-source.md"},
+		{file: "features/output-dir.md"},
+		{file: "features/output-split.md"},
+		{file: "features/params.md"},
+		{file: "features/round-trip-report.md"},
 		{file: "features/use-as-library.md"},
 
 		{file: "testdata/converter-numeric.md"},
+		{file: "testdata/converter-numeric-narrowing.md"},
+		{file: "testdata/converter-time.md"},
+		{file: "testdata/converter-strconv.md"},
+		{file: "testdata/converter-text.md"},
+		{file: "testdata/converter-discovery.md"},
 		{file: "testdata/import.md"},
 		{file: "testdata/placeholder.md"},
 		{file: "testdata/decorator.md"},
 		{file: "testdata/decorator-mode.md"},
 
 		{file: "testdata/field-interceptor.md"},
+		{file: "testdata/return-error.md"},
 	}
 
 	for _, tc := range cases {
@@ -11,6 +11,11 @@ func runGenerate(cmd GenerateCmd, logger *slog.Logger) error {
 		WorkingDir:                cmd.WorkingDir,
 		ConfigFileName:            cmd.ConfigFileName,
 		DryRun:                    cmd.DryRun,
+		Check:                     cmd.Check,
+		Prune:                     cmd.Prune,
+		NoValidate:                cmd.NoValidate,
+		KeepGoing:                 cmd.KeepGoing,
+		Jobs:                      cmd.Jobs,
 		PrintRegisteredConverters: true,
 		Logger:                    logger,
 	})
@@ -16,6 +16,11 @@ type GenerateCmd struct {
 	WorkingDir     string `arg:"-w,--working-dir" help:"Base directory" default:"." placeholder:"DIR"`
 	ConfigFileName string `arg:"-c,--config" help:"Config file name" default:"mapper.pkl" placeholder:"NAME"`
 	DryRun         bool   `arg:"-d,--dry-run" help:"Preview changes without writing to disk"`
+	Check          bool   `arg:"--check" help:"Verify generated files are up to date without writing, exit non-zero if not"`
+	Prune          bool   `arg:"--prune" help:"Delete generated files which are not produced by this run, list them with --dry-run"`
+	NoValidate     bool   `arg:"--no-validate" help:"Skip type-checking generated code before writing"`
+	KeepGoing      bool   `arg:"-k,--keep-going" help:"Generate and write other packages if a package fails, exit non-zero at the end"`
+	Jobs           int    `arg:"-j,--jobs" help:"Number of packages generated concurrently, packages sharing an output directory are generated in order" default:"1" placeholder:"N"`
 }
 
 type TestCmd struct {
@@ -55,7 +60,7 @@ func Run(args Args) {
 	handleError := func(err error) {
 		if err != nil {
 			logger.Error(util.ColorRed(err.Error()))
-			return
+			os.Exit(1)
 		}
 		logger.Debug(fmt.Sprintf("Total LookUp hits %d", gomappergen.LookUpTotalHits))
 		logger.Debug("")
@@ -91,6 +96,11 @@ func Run(args Args) {
 			WorkingDir:     absPath,
 			ConfigFileName: args.Generate.ConfigFileName,
 			DryRun:         args.Generate.DryRun,
+			Check:          args.Generate.Check,
+			Prune:          args.Generate.Prune,
+			NoValidate:     args.Generate.NoValidate,
+			KeepGoing:      args.Generate.KeepGoing,
+			Jobs:           args.Generate.Jobs,
 		}, logger))
 
 	default:
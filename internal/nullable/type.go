@@ -0,0 +1,234 @@
+// Package nullable emits the code which converts a nullable library type, such as pgtype.Text
+// or sql.NullString, which is a struct of a value and a valid flag. It is shared by the pgtype
+// and sql library converters.
+package nullable
+
+import (
+	"go/types"
+
+	"github.com/dave/jennifer/jen"
+	gen "github.com/toniphan21/go-mapper-gen"
+)
+
+// Type is a nullable type which is converted from/to Target, a pointer of its value type, and
+// from/to other types via gen.GeneratedTypeOrchestrator. A conversion between the nullable
+// type and the value type itself is controlled by gen.NullMode.
+type Type struct {
+	Generated         gen.TypeInfo
+	Target            types.Type
+	ValuePropertyName string
+	ValidPropertyName string
+
+	valueType    types.Type
+	orchestrator gen.GeneratedTypeOrchestrator
+}
+
+// New returns the nullable type generated, target must be a pointer of its value type which
+// is the field valuePropertyName, validPropertyName is "Valid" if it is nil.
+func New(generated, target gen.TypeInfo, valuePropertyName string, validPropertyName *string) *Type {
+	n := &Type{
+		Generated:         generated,
+		Target:            target.ToType(),
+		ValuePropertyName: valuePropertyName,
+		ValidPropertyName: "Valid",
+	}
+	if validPropertyName != nil {
+		n.ValidPropertyName = *validPropertyName
+	}
+
+	n.valueType = n.Target.(*types.Pointer).Elem()
+	n.orchestrator = gen.GeneratedTypeOrchestrator{
+		Generated:                generated,
+		Target:                   target,
+		GeneratedToTarget:        n.toTarget,
+		GeneratedToTargetToOther: n.toTargetToOther,
+		TargetToGenerated:        n.fromTarget,
+		OtherToTargetToGenerated: n.otherToTargetToNullable,
+	}
+	return n
+}
+
+// CanConvert reports whether converter, which converts the nullable type, can convert
+// sourceType to targetType in the given mode.
+func (n *Type) CanConvert(converter gen.Converter, ctx gen.LookupContext, mode gen.NullMode, targetType, sourceType types.Type) bool {
+	if mode != gen.NullModePointer && n.isValueConversion(targetType, sourceType) {
+		return true
+	}
+	return n.orchestrator.CanConvert(converter, ctx, targetType, sourceType)
+}
+
+// Convert emits the code of converter, which converts the nullable type, in the given mode.
+func (n *Type) Convert(converter gen.Converter, ctx gen.ConverterContext, mode gen.NullMode, target, source gen.Symbol) jen.Code {
+	if mode != gen.NullModePointer && n.isValueConversion(target.Type, source.Type) {
+		if n.is(source.Type) {
+			return n.toValue(mode, target, source)
+		}
+		return n.fromValue(ctx, mode, target, source)
+	}
+	return n.orchestrator.PerformConvert(converter, ctx, target, source)
+}
+
+func (n *Type) is(t types.Type) bool {
+	return gen.TypeUtil.MatchNamedType(t, n.Generated.PkgPath, n.Generated.TypeName)
+}
+
+// isValueConversion reports whether it is a conversion between the nullable type and
+// its non-pointer value type, which is controlled by gen.NullMode.
+func (n *Type) isValueConversion(targetType, sourceType types.Type) bool {
+	if n.is(sourceType) {
+		return gen.TypeUtil.IsIdentical(targetType, n.valueType)
+	}
+
+	if n.is(targetType) {
+		return gen.TypeUtil.IsIdentical(sourceType, n.valueType)
+	}
+	return false
+}
+
+func (n *Type) toTarget(ctx gen.ConverterContext, target, source gen.Symbol) jen.Code {
+	/** generated code:
+	if {source}.[[ValidPropertyName]] {
+		{target} = &{source}.[[ValuePropertyName]]
+	}
+	*/
+	return jen.If(source.Expr().Dot(n.ValidPropertyName)).BlockFunc(func(g *jen.Group) {
+		g.Add(target.Expr()).Op("=").Op("&").Add(source.Expr().Dot(n.ValuePropertyName))
+	})
+}
+
+func (n *Type) toTargetToOther(ctx gen.ConverterContext, target, source gen.Symbol, oc gen.Converter) jen.Code {
+	/** generated code:
+	var v0 [[Target]]
+	if {source}.[[ValidPropertyName]] {
+		v0 = &{source}.[[ValuePropertyName]]
+	}
+
+	// other converter converts v0 to target
+	*/
+	// first convert n.Generated to n.Target hold in a temporary variable
+	varName := ctx.NextVarName()
+	code := jen.Line().Var().Id(varName).Add(gen.GeneratorUtil.TypeToJenCode(n.Target)).Line()
+
+	code = code.If(source.Expr().Dot(n.ValidPropertyName)).BlockFunc(func(g *jen.Group) {
+		g.Add(jen.Id(varName)).Op("=").Op("&").Add(source.Expr().Dot(n.ValuePropertyName))
+	}).Line()
+
+	// then call other converter to convert the variable to target
+	sourceSymbol := gen.Symbol{VarName: varName, Type: n.Target}
+	convertedCode := oc.ConvertField(ctx, target, sourceSymbol)
+	if convertedCode == nil {
+		return nil
+	}
+	return code.Add(convertedCode).Line()
+}
+
+func (n *Type) fromTarget(ctx gen.ConverterContext, target, source gen.Symbol) jen.Code {
+	/** generated code:
+	if {source} != nil {
+		{target} = [[Nullable]]{[[ValuePropertyName]]: *{source}, [[ValidPropertyName]]: true}
+	}
+	*/
+	code := jen.If(source.Expr().Op("!=").Nil())
+	code = code.BlockFunc(func(g *jen.Group) {
+		g.Add(target.Expr()).Op("=").Add(
+			jen.Qual(n.Generated.PkgPath, n.Generated.TypeName).Values(jen.DictFunc(func(d jen.Dict) {
+				d[jen.Id(n.ValuePropertyName)] = jen.Op("*").Add(source.Expr())
+				d[jen.Id(n.ValidPropertyName)] = jen.Lit(true)
+			})),
+		)
+	})
+
+	return code
+}
+
+func (n *Type) otherToTargetToNullable(ctx gen.ConverterContext, target, source gen.Symbol, oc gen.Converter) jen.Code {
+	/** generated code:
+	var v0 [[Target]]
+	// other converter converts source to v0
+
+	if v0 != nil {
+		{target} = [[Nullable]]{[[ValuePropertyName]]: *v0, [[ValidPropertyName]]: true}
+	}
+	*/
+	// first convert source to [[Target]] hold in a temporary variable
+	varName := ctx.NextVarName()
+	code := jen.Line().Var().Id(varName).Add(gen.GeneratorUtil.TypeToJenCode(n.Target)).Line()
+	targetSymbol := gen.Symbol{VarName: varName, Type: n.Target, Metadata: gen.SymbolMetadata{IsVariable: true, HasZeroValue: true}}
+	convertedCode := oc.ConvertField(ctx, targetSymbol, source)
+	if convertedCode == nil {
+		return nil
+	}
+	code.Add(convertedCode).Line()
+
+	// then convert variable to target
+	code = code.Add(jen.If(jen.Id(varName).Op("!=").Nil()))
+	code = code.BlockFunc(func(g *jen.Group) {
+		g.Add(target.Expr()).Op("=").Add(
+			jen.Qual(n.Generated.PkgPath, n.Generated.TypeName).Values(jen.DictFunc(func(d jen.Dict) {
+				d[jen.Id(n.ValuePropertyName)] = jen.Op("*").Add(jen.Id(varName))
+				d[jen.Id(n.ValidPropertyName)] = jen.Lit(true)
+			})),
+		)
+	})
+	return code
+}
+
+func (n *Type) toValue(mode gen.NullMode, target, source gen.Symbol) jen.Code {
+	/** generated code:
+	if {source}.[[ValidPropertyName]] {
+		{target} = {source}.[[ValuePropertyName]]
+	}
+
+	// or if mode is always-valid
+	{target} = {source}.[[ValuePropertyName]]
+	*/
+	if mode == gen.NullModeAlwaysValid {
+		return target.Expr().Op("=").Add(source.Expr().Dot(n.ValuePropertyName))
+	}
+
+	code := jen.If(source.Expr().Dot(n.ValidPropertyName)).BlockFunc(func(g *jen.Group) {
+		g.Add(target.Expr()).Op("=").Add(source.Expr().Dot(n.ValuePropertyName))
+	})
+
+	if !target.Metadata.HasZeroValue {
+		code = code.Else().BlockFunc(func(g *jen.Group) {
+			gc := g.Var().Id("zero").Add(gen.GeneratorUtil.TypeToJenCode(target.Type)).Line()
+			gc.Add(target.Expr()).Op("=").Id("zero")
+		})
+	}
+	return code
+}
+
+func (n *Type) fromValue(ctx gen.ConverterContext, mode gen.NullMode, target, source gen.Symbol) jen.Code {
+	/** generated code:
+	{target} = [[Nullable]]{[[ValuePropertyName]]: {source}, [[ValidPropertyName]]: true}
+
+	// or if mode is valid-if-non-zero
+	var v0 [[Value]]
+	if {source} != v0 {
+		{target} = [[Nullable]]{[[ValuePropertyName]]: {source}, [[ValidPropertyName]]: true}
+	}
+	*/
+	value := jen.Qual(n.Generated.PkgPath, n.Generated.TypeName).Values(jen.DictFunc(func(d jen.Dict) {
+		d[jen.Id(n.ValuePropertyName)] = source.Expr()
+		d[jen.Id(n.ValidPropertyName)] = jen.Lit(true)
+	}))
+
+	if mode != gen.NullModeValidIfNonZero {
+		return target.Expr().Op("=").Add(value)
+	}
+
+	varName := ctx.NextVarName()
+	code := jen.Line().Var().Id(varName).Add(gen.GeneratorUtil.TypeToJenCode(n.valueType)).Line()
+	code = code.If(source.Expr().Op("!=").Id(varName)).BlockFunc(func(g *jen.Group) {
+		g.Add(target.Expr()).Op("=").Add(value)
+	})
+
+	if !target.Metadata.HasZeroValue {
+		code = code.Else().BlockFunc(func(g *jen.Group) {
+			gc := g.Var().Id("zero").Add(gen.GeneratorUtil.TypeToJenCode(target.Type)).Line()
+			gc.Add(target.Expr()).Op("=").Id("zero")
+		})
+	}
+	return code
+}
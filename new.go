@@ -1,19 +1,58 @@
 package gomappergen
 
 import (
+	"fmt"
 	"log/slog"
 
 	"golang.org/x/tools/go/packages"
 )
 
 type Generator interface {
+	// Generate generates the mappers of a package, a returned error is a *GenerateError. It is
+	// safe to generate different packages concurrently if the FileManager is safe for
+	// concurrent use, which the DefaultFileManager is.
 	Generate(currentPkg *packages.Package, configs []PackageConfig) error
+
+	// Stats returns the statistics of the packages generated so far.
+	Stats() GeneratorStats
+}
+
+// GeneratorStats are the statistics of a generator.
+type GeneratorStats struct {
+	// LookUpCacheHits and LookUpCacheMisses count the converter lookups which are answered by,
+	// or missed, the lookup cache of the generator.
+	LookUpCacheHits   uint64
+	LookUpCacheMisses uint64
+}
+
+// GenerateError is returned by Generator.Generate if a package cannot be generated, MapperName
+// is the mapper which caused the error, or empty if the error is not caused by a mapper.
+type GenerateError struct {
+	PkgPath    string
+	MapperName string
+	Err        error
+}
+
+func (e *GenerateError) Error() string {
+	if e.MapperName == "" {
+		return fmt.Sprintf("package %s: %v", e.PkgPath, e.Err)
+	}
+	return fmt.Sprintf("package %s, mapper %s: %v", e.PkgPath, e.MapperName, e.Err)
+}
+
+func (e *GenerateError) Unwrap() error {
+	return e.Err
 }
 
 type Options struct {
 	Parser      Parser
 	FileManager FileManager
+	Registry    *Registry
 	Logger      *slog.Logger
+
+	// LookUpCacheSize is the number of converter lookup results the generator keeps, the cache
+	// is disabled if it is not positive.
+	LookUpCacheSize int
 }
 
 type OptionFunc func(*Options)
@@ -22,17 +61,21 @@ func New(parser Parser, config Config, options ...OptionFunc) Generator {
 	o := &Options{
 		Parser:      parser,
 		FileManager: DefaultFileManager(),
+		Registry:    DefaultRegistry(),
 		Logger:      NewNoopLogger(),
+
+		LookUpCacheSize: DefaultLookUpCacheSize,
 	}
 
 	for _, fn := range options {
 		fn(o)
 	}
 
-	initRegisteredConverters(parser, config, o.Logger)
+	registry := o.Registry.snapshot(parser, config, o.Logger)
 	return &generatorImpl{
 		parser:      o.Parser,
 		fileManager: o.FileManager,
+		scope:       newConverterScope(registry, o.LookUpCacheSize),
 		logger:      o.Logger,
 	}
 }
@@ -48,3 +91,18 @@ func WithFileManager(fileManager FileManager) OptionFunc {
 		o.FileManager = fileManager
 	}
 }
+
+// WithRegistry makes the generator use the converters of registry instead of the DefaultRegistry.
+func WithRegistry(registry *Registry) OptionFunc {
+	return func(o *Options) {
+		o.Registry = registry
+	}
+}
+
+// WithLookUpCacheSize sets the number of converter lookup results the generator keeps, a size
+// which is not positive disables the cache.
+func WithLookUpCacheSize(size int) OptionFunc {
+	return func(o *Options) {
+		o.LookUpCacheSize = size
+	}
+}
@@ -3,9 +3,14 @@ package gomappergen
 import (
 	"fmt"
 	"go/ast"
+	"go/constant"
 	"go/token"
 	"go/types"
+	"slices"
+	"strings"
+	"sync"
 
+	"github.com/IGLOU-EU/go-wildcard"
 	"github.com/toniphan21/go-mapper-gen/internal/util"
 	"golang.org/x/tools/go/packages"
 )
@@ -33,6 +38,16 @@ type FuncInfo struct {
 	Results     []types.Type
 }
 
+type EnumInfo struct {
+	Type   types.Type
+	Values []EnumValueInfo
+}
+
+type EnumValueInfo struct {
+	Name  string
+	Value constant.Value
+}
+
 type Parser interface {
 	SourceDir() string
 
@@ -45,6 +60,23 @@ type Parser interface {
 	FindVariableMethods(pkgPath string, name string) []FuncInfo
 }
 
+// ExtendedParser is implemented by a Parser which supports the lookups of converter function
+// patterns, the //mapper:converter marker, parameter types and enums. The features which use
+// them are not available with a Parser which does not implement it, DefaultParser does.
+type ExtendedParser interface {
+	Parser
+
+	FindFunctions(pkgPath string, pattern string) []FuncInfo
+
+	FindVariables(pkgPath string, pattern string) []string
+
+	FindMarkedFunctions(marker string) []FuncInfo
+
+	FindType(pkgPath string, name string) (types.Type, bool)
+
+	FindEnum(pkgPath string, name string) (EnumInfo, bool)
+}
+
 func DefaultParser(dir string) (Parser, error) {
 	cfg := &packages.Config{
 		Mode: packages.NeedName | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedSyntax | packages.NeedDeps | packages.NeedImports,
@@ -61,6 +93,7 @@ func DefaultParser(dir string) (Parser, error) {
 		dir:            dir,
 		config:         cfg,
 		sourcePackages: pkgs,
+		loaded:         make(map[string]*packages.Package),
 	}, nil
 }
 
@@ -68,6 +101,11 @@ type parserImpl struct {
 	dir            string
 	config         *packages.Config
 	sourcePackages []*packages.Package
+
+	// loaded are the packages outside the source packages by path, nil if a package
+	// cannot be loaded
+	mu     sync.Mutex
+	loaded map[string]*packages.Package
 }
 
 func (p *parserImpl) SourceDir() string {
@@ -79,68 +117,167 @@ func (p *parserImpl) SourcePackages() []*packages.Package {
 }
 
 func (p *parserImpl) FindStruct(pkgPath string, name string) (StructInfo, bool) {
-	for _, pkg := range p.sourcePackages {
-		if pkg.PkgPath == pkgPath {
-			return p.findStructFromPkg(pkg, name)
+	pkg, ok := p.findPackage(pkgPath)
+	if ok {
+		return p.findStructFromPkg(pkg, name)
+	}
+
+	if pkg != nil {
+		for _, v := range pkg.Errors {
+			fmt.Println(util.ColorYellow(fmt.Sprintf("Warning: %v", v)))
 		}
 	}
+	return StructInfo{}, false
+}
 
-	pkgs, err := packages.Load(p.config, pkgPath)
-	if err != nil {
-		return StructInfo{}, false
+func (p *parserImpl) FindFunction(pkgPath string, name string) (FuncInfo, bool) {
+	pkg, ok := p.findPackage(pkgPath)
+	if !ok {
+		return FuncInfo{}, false
+	}
+	return p.findFunctionFromPkg(pkg, name)
+}
+
+func (p *parserImpl) FindVariableMethods(pkgPath string, variableName string) []FuncInfo {
+	pkg, ok := p.findPackage(pkgPath)
+	if !ok {
+		return nil
 	}
+	return p.findVariableMethodsFromPkg(pkg, variableName)
+}
 
-	for _, pkg := range pkgs {
-		if pkg.PkgPath != pkgPath {
+// FindFunctions returns the exported package-level functions whose names match the
+// wildcard pattern, sorted by name. Generic and variadic functions are skipped.
+func (p *parserImpl) FindFunctions(pkgPath string, pattern string) []FuncInfo {
+	pkg, ok := p.findPackage(pkgPath)
+	if !ok {
+		return nil
+	}
+
+	var result []FuncInfo
+	scope := pkg.Types.Scope()
+	for _, name := range scope.Names() {
+		if !token.IsExported(name) || !wildcard.Match(pattern, name) {
+			continue
+		}
+
+		fn, ok := scope.Lookup(name).(*types.Func)
+		if !ok {
 			continue
 		}
 
-		if len(pkg.Errors) == 0 {
-			return p.findStructFromPkg(pkg, name)
+		if sig := fn.Signature(); sig.TypeParams().Len() != 0 || sig.Variadic() {
+			continue
 		}
 
-		for _, v := range pkg.Errors {
-			fmt.Println(util.ColorYellow(fmt.Sprintf("Warning: %v", v)))
+		if fn, ok := p.findFunctionFromPkg(pkg, name); ok {
+			result = append(result, fn)
 		}
 	}
-	return StructInfo{}, false
+	return result
 }
 
-func (p *parserImpl) FindFunction(pkgPath string, name string) (FuncInfo, bool) {
+// FindVariables returns the names of the exported package-level variables which match the
+// wildcard pattern, sorted by name.
+func (p *parserImpl) FindVariables(pkgPath string, pattern string) []string {
+	pkg, ok := p.findPackage(pkgPath)
+	if !ok {
+		return nil
+	}
+
+	var result []string
+	scope := pkg.Types.Scope()
+	for _, name := range scope.Names() {
+		if !token.IsExported(name) || !wildcard.Match(pattern, name) {
+			continue
+		}
+
+		if _, ok := scope.Lookup(name).(*types.Var); ok {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// FindMarkedFunctions returns the package-level functions in the source packages which
+// have the marker line in their doc comment, e.g. //mapper:converter.
+func (p *parserImpl) FindMarkedFunctions(marker string) []FuncInfo {
+	var result []FuncInfo
 	for _, pkg := range p.sourcePackages {
-		if pkg.PkgPath == pkgPath {
-			return p.findFunctionFromPkg(pkg, name)
+		for _, f := range pkg.Syntax {
+			for _, decl := range f.Decls {
+				fd, ok := decl.(*ast.FuncDecl)
+				if !ok || fd.Recv != nil || fd.Doc == nil {
+					continue
+				}
+
+				marked := slices.ContainsFunc(fd.Doc.List, func(c *ast.Comment) bool {
+					return strings.TrimSpace(c.Text) == marker
+				})
+				if !marked {
+					continue
+				}
+
+				if fn, ok := p.findFunctionFromPkg(pkg, fd.Name.Name); ok {
+					result = append(result, fn)
+				}
+			}
 		}
 	}
+	return result
+}
 
-	pkgs, err := packages.Load(p.config, pkgPath)
-	if err != nil {
-		return FuncInfo{}, false
+// FindType returns the type declared with the name in the package.
+func (p *parserImpl) FindType(pkgPath string, name string) (types.Type, bool) {
+	pkg, ok := p.findPackage(pkgPath)
+	if !ok {
+		return nil, false
 	}
 
-	for _, pkg := range pkgs {
-		if pkg.PkgPath == pkgPath && len(pkg.Errors) == 0 {
-			return p.findFunctionFromPkg(pkg, name)
-		}
+	tn, ok := pkg.Types.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil, false
 	}
-	return FuncInfo{}, false
+	return tn.Type(), true
 }
 
-func (p *parserImpl) FindVariableMethods(pkgPath string, variableName string) []FuncInfo {
+func (p *parserImpl) FindEnum(pkgPath string, name string) (EnumInfo, bool) {
+	pkg, ok := p.findPackage(pkgPath)
+	if !ok {
+		return EnumInfo{}, false
+	}
+	return p.findEnumFromPkg(pkg, name)
+}
+
+// findPackage returns a source package, or loads a package outside the source packages once.
+// A loaded package which has errors is returned with false.
+func (p *parserImpl) findPackage(pkgPath string) (*packages.Package, bool) {
 	for _, pkg := range p.sourcePackages {
 		if pkg.PkgPath == pkgPath {
-			return p.findVariableMethodsFromPkg(pkg, variableName)
+			return pkg, true
 		}
 	}
 
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pkg, have := p.loaded[pkgPath]
+	if !have {
+		pkg = p.loadPackage(pkgPath)
+		p.loaded[pkgPath] = pkg
+	}
+	return pkg, pkg != nil && len(pkg.Errors) == 0
+}
+
+func (p *parserImpl) loadPackage(pkgPath string) *packages.Package {
 	pkgs, err := packages.Load(p.config, pkgPath)
 	if err != nil {
 		return nil
 	}
 
 	for _, pkg := range pkgs {
-		if pkg.PkgPath == pkgPath && len(pkg.Errors) == 0 {
-			return p.findVariableMethodsFromPkg(pkg, variableName)
+		if pkg.PkgPath == pkgPath {
+			return pkg
 		}
 	}
 	return nil
@@ -389,6 +526,51 @@ func (p *parserImpl) findVariableMethodsFromPkg(pkg *packages.Package, variableN
 	return methods
 }
 
+// findEnumFromPkg collects the constants declared with the named type, in
+// declaration order. A type is an enum when its underlying type is a string
+// or an integer and at least one constant of that type exists.
+func (p *parserImpl) findEnumFromPkg(pkg *packages.Package, name string) (EnumInfo, bool) {
+	scope := pkg.Types.Scope()
+	tn, ok := scope.Lookup(name).(*types.TypeName)
+	if !ok {
+		return EnumInfo{}, false
+	}
+
+	named, ok := tn.Type().(*types.Named)
+	if !ok {
+		return EnumInfo{}, false
+	}
+
+	basic, ok := named.Underlying().(*types.Basic)
+	if !ok || basic.Info()&(types.IsInteger|types.IsString) == 0 {
+		return EnumInfo{}, false
+	}
+
+	var consts []*types.Const
+	for _, n := range scope.Names() {
+		c, ok := scope.Lookup(n).(*types.Const)
+		if !ok || !types.Identical(c.Type(), named) {
+			continue
+		}
+		consts = append(consts, c)
+	}
+
+	if len(consts) == 0 {
+		return EnumInfo{}, false
+	}
+
+	slices.SortStableFunc(consts, func(a, b *types.Const) int {
+		return int(a.Pos()) - int(b.Pos())
+	})
+
+	values := make([]EnumValueInfo, len(consts))
+	for i, c := range consts {
+		values[i] = EnumValueInfo{Name: c.Name(), Value: c.Val()}
+	}
+
+	return EnumInfo{Type: named, Values: values}, true
+}
+
 func (p *parserImpl) getTypesFromTuple(tup *types.Tuple) []types.Type {
 	if tup == nil {
 		return nil
@@ -401,3 +583,5 @@ func (p *parserImpl) getTypesFromTuple(tup *types.Tuple) []types.Type {
 }
 
 var _ Parser = (*parserImpl)(nil)
+
+var _ ExtendedParser = (*parserImpl)(nil)
@@ -0,0 +1,29 @@
+package gomappergen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/toniphan21/go-mapper-gen/internal/setup/file"
+)
+
+func Test_parserImpl_findPackage_loadsPackageOnce(t *testing.T) {
+	parser, err := Test.Parse(t, []file.File{
+		file.New("go.mod", Test.MakeGoModFileContent("github.com/toniphan21/go-mapper-gen/test", nil, nil)),
+		file.New("code.go", Test.FileLines(`package test`)),
+	})
+	require.NoError(t, err)
+	p := parser.(*parserImpl)
+
+	pkg, ok := p.findPackage("strings")
+	require.True(t, ok)
+
+	again, ok := p.findPackage("strings")
+	assert.True(t, ok)
+	assert.Same(t, pkg, again)
+
+	_, ok = p.findPackage("github.com/toniphan21/go-mapper-gen/test/missing")
+	assert.False(t, ok)
+	assert.Contains(t, p.loaded, "github.com/toniphan21/go-mapper-gen/test/missing")
+}
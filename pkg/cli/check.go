@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/toniphan21/go-mapper-gen/internal/util"
+)
+
+// ErrStaleFiles is returned by Run in check mode if a generated file is stale or missing.
+var ErrStaleFiles = errors.New("generated files are not up to date")
+
+// checkFiles compares the generated files with the files on disk and prints a unified diff
+// for each file which is stale or missing. Orphaned files are reported as stale as well.
+func checkFiles(workingDir string, contents map[string][]byte, orphans []string, logger *slog.Logger) error {
+	var stale []string
+	for _, p := range orphans {
+		logger.Info(util.ColorGreen(appName) + " found orphaned file " + util.ColorRed(filepath.Join(workingDir, p)))
+		stale = append(stale, p)
+	}
+
+	for _, p := range slices.Sorted(maps.Keys(contents)) {
+		rp := filepath.Join(workingDir, p)
+		expected := string(contents[p])
+
+		actual, err := os.ReadFile(rp)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		if err == nil && string(actual) == expected {
+			logger.Debug(util.ColorGreen(appName) + " checked file " + util.ColorBlue(rp))
+			continue
+		}
+
+		stale = append(stale, p)
+		fromFile, fromLines := p, difflib.SplitLines(string(actual))
+		if err != nil {
+			logger.Info(util.ColorGreen(appName) + " found missing file " + util.ColorRed(rp))
+			fromFile, fromLines = "/dev/null", nil
+		} else {
+			logger.Info(util.ColorGreen(appName) + " found stale file " + util.ColorRed(rp))
+		}
+
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        fromLines,
+			B:        difflib.SplitLines(expected),
+			FromFile: fromFile,
+			ToFile:   p,
+			Context:  3,
+		})
+		if err != nil {
+			return err
+		}
+		printUnifiedDiff(diff, logger)
+	}
+
+	if len(stale) > 0 {
+		return fmt.Errorf("%w: %s", ErrStaleFiles, strings.Join(stale, ", "))
+	}
+	logger.Info(util.ColorGreen(appName) + " generated files are up to date")
+	return nil
+}
+
+func printUnifiedDiff(diff string, logger *slog.Logger) {
+	for _, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			logger.Info(line)
+		case strings.HasPrefix(line, "+"):
+			logger.Info(util.ColorGreen(line))
+		case strings.HasPrefix(line, "-"):
+			logger.Info(util.ColorRed(line))
+		case strings.HasPrefix(line, "@@"):
+			logger.Info(util.ColorCyan(line))
+		default:
+			logger.Info(line)
+		}
+	}
+}
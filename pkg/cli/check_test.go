@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gen "github.com/toniphan21/go-mapper-gen"
+)
+
+func Test_checkFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "fresh.go"), []byte("package a\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "stale.go"), []byte("package a\n"), 0644))
+
+	t.Run("up to date", func(t *testing.T) {
+		contents := map[string][]byte{"fresh.go": []byte("package a\n")}
+
+		assert.NoError(t, checkFiles(dir, contents, nil, gen.NewNoopLogger()))
+	})
+
+	t.Run("stale, missing and orphaned files", func(t *testing.T) {
+		contents := map[string][]byte{
+			"fresh.go":       []byte("package a\n"),
+			"stale.go":       []byte("package b\n"),
+			"missing/gen.go": []byte("package missing\n"),
+		}
+
+		err := checkFiles(dir, contents, []string{"orphan.go"}, gen.NewNoopLogger())
+
+		assert.ErrorIs(t, err, ErrStaleFiles)
+		assert.EqualError(t, err, ErrStaleFiles.Error()+": orphan.go, missing/gen.go, stale.go")
+
+		// nothing is written in check mode
+		content, err := os.ReadFile(filepath.Join(dir, "stale.go"))
+		require.NoError(t, err)
+		assert.Equal(t, "package a\n", string(content))
+		assert.NoFileExists(t, filepath.Join(dir, "missing/gen.go"))
+	})
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"bufio"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	gen "github.com/toniphan21/go-mapper-gen"
+)
+
+// generatedHeaderPrefix is the beginning of the header comment written by FileManager, the version
+// part is not included so files generated by other versions are detected as well.
+const generatedHeaderPrefix = "// Code generated by " + gen.BinaryName + " "
+
+// findOrphanedFiles returns files under workingDir which carry the generated header but are not
+// in contents, relative to workingDir. Hidden directories, vendor, testdata and nested modules are
+// skipped because their files are not generated from this configuration.
+func findOrphanedFiles(workingDir string, contents map[string][]byte) ([]string, error) {
+	var orphans []string
+	err := filepath.WalkDir(workingDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if path == workingDir {
+				return nil
+			}
+			name := d.Name()
+			if strings.HasPrefix(name, ".") || name == "vendor" || name == "testdata" {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(workingDir, path)
+		if err != nil {
+			return err
+		}
+		if _, have := contents[rel]; have {
+			return nil
+		}
+
+		generated, err := hasGeneratedHeader(path)
+		if err != nil {
+			return err
+		}
+		if generated {
+			orphans = append(orphans, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Sort(orphans)
+	return orphans, nil
+}
+
+func hasGeneratedHeader(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	if !scanner.Scan() {
+		return false, scanner.Err()
+	}
+	return strings.HasPrefix(scanner.Text(), generatedHeaderPrefix), nil
+}
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_findOrphanedFiles(t *testing.T) {
+	dir := t.TempDir()
+	generated := []byte(generatedHeaderPrefix + "- v0.1.0, DO NOT EDIT.\n\npackage a\n")
+	files := map[string][]byte{
+		"gen_mapper.go":             generated,
+		"old_mapper.go":             generated,
+		"code.go":                   []byte("package a\n"),
+		"sub/old_mapper.go":         generated,
+		"sub/readme.txt":            generated,
+		".hidden/old_mapper.go":     generated,
+		"vendor/old_mapper.go":      generated,
+		"testdata/old_mapper.go":    generated,
+		"nested/go.mod":             []byte("module nested\n"),
+		"nested/old_mapper.go":      generated,
+		"other/gen_mapper_other.go": []byte("// Code generated by another tool. DO NOT EDIT.\n\npackage other\n"),
+	}
+	for p, content := range files {
+		path := filepath.Join(dir, p)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, content, 0644))
+	}
+
+	orphans, err := findOrphanedFiles(dir, map[string][]byte{"gen_mapper.go": generated})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"old_mapper.go", filepath.Join("sub", "old_mapper.go")}, orphans)
+}
@@ -1,16 +1,24 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 
 	gen "github.com/toniphan21/go-mapper-gen"
 	"github.com/toniphan21/go-mapper-gen/converters/grpc"
 	"github.com/toniphan21/go-mapper-gen/converters/pgtype"
 	"github.com/toniphan21/go-mapper-gen/converters/sql"
+	"github.com/toniphan21/go-mapper-gen/converters/uuid"
 	"github.com/toniphan21/go-mapper-gen/internal/util"
+	"golang.org/x/tools/go/packages"
 )
 
 const appName = "go-mapper-gen"
@@ -20,6 +28,11 @@ type RunCommand struct {
 	WorkingDir                string
 	ConfigFileName            string
 	DryRun                    bool
+	Check                     bool
+	Prune                     bool
+	NoValidate                bool
+	KeepGoing                 bool
+	Jobs                      int
 	PrintRegisteredConverters bool
 	Parser                    gen.Parser
 	FileManager               gen.FileManager
@@ -28,13 +41,38 @@ type RunCommand struct {
 	RegisterConverters        func()
 }
 
+// GenerateErrors is returned by Run if packages cannot be generated.
+type GenerateErrors []*gen.GenerateError
+
+func (e GenerateErrors) Error() string {
+	lines := []string{fmt.Sprintf("%d package(s) failed to generate:", len(e))}
+	for _, err := range e {
+		lines = append(lines, "  - "+err.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (e GenerateErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Run generates the configured packages and writes the generated files. It stops at the first
+// package which fails to generate unless KeepGoing is set, in that case the other packages are
+// generated and written, and the failures are returned as GenerateErrors. Up to Jobs packages
+// are generated concurrently, the output and the errors are in the same order regardless of Jobs.
 func Run(cmd RunCommand) error {
 	logger := cmd.Logger
 	if logger == nil {
 		logger = slog.Default()
 	}
 
-	if cmd.DryRun {
+	if cmd.Check {
+		logger.Info(util.ColorGreen(appName) + " " + gen.Version() + " in CHECK mode")
+	} else if cmd.DryRun {
 		logger.Info(util.ColorGreen(appName) + " " + gen.Version() + " in DRY mode")
 	} else {
 		logger.Info(util.ColorGreen(appName) + " " + gen.Version())
@@ -89,46 +127,227 @@ func Run(cmd RunCommand) error {
 
 	logger.Info(util.ColorGreen(appName) + " initiated successfully")
 
+	var targets []*packages.Package
 	for _, pkg := range parser.SourcePackages() {
-		pkgPath := pkg.PkgPath
-		configs, have := parsedConfig.Packages[pkgPath]
-		if !have {
-			logger.Debug(fmt.Sprintf("package %s has no config, skipped", util.ColorCyan(pkgPath)))
+		if _, have := parsedConfig.Packages[pkg.PkgPath]; !have {
+			logger.Debug(fmt.Sprintf("package %s has no config, skipped", util.ColorCyan(pkg.PkgPath)))
 			continue
 		}
+		targets = append(targets, pkg)
+	}
 
-		logger.Info(util.ColorGreen(appName) + " is generating for package " + util.ColorCyan(pkgPath))
-		err = generator.Generate(pkg, configs)
-		if err != nil {
-			logger.Error(fmt.Sprintf("cannot generate for package %s: %s", util.ColorCyan(pkgPath), util.ColorRed(err.Error())))
+	generateErrs := generatePackages(generator, parser.SourceDir(), targets, parsedConfig.Packages, cmd.Jobs, cmd.KeepGoing, logger)
+	stats := generator.Stats()
+	logger.Debug(fmt.Sprintf("LookUp cache hits %d, misses %d", stats.LookUpCacheHits, stats.LookUpCacheMisses))
+
+	if len(generateErrs) > 0 && !cmd.KeepGoing {
+		return generateErrs
+	}
+
+	var errs []error
+	if len(generateErrs) > 0 {
+		errs = append(errs, generateErrs)
+	}
+
+	contents, err := renderFiles(fm.JenFiles())
+	if err != nil {
+		logger.Error(util.ColorRed("failed to render generated files."))
+		return err
+	}
+
+	var orphans []string
+	if cmd.Prune {
+		if len(generateErrs) > 0 {
+			logger.Warn(util.ColorYellow("some packages failed to generate, orphaned files are not pruned"))
+		} else {
+			orphans, err = findOrphanedFiles(cmd.WorkingDir, contents)
+			if err != nil {
+				logger.Error(util.ColorRed("failed to find orphaned generated files."))
+				return err
+			}
 		}
 	}
 
-	outs := fm.JenFiles()
-	if len(outs) == 0 {
+	if cmd.Check {
+		logger.Info(util.ColorGreen(appName) + " is checking generated files on disk")
+		return errors.Join(append(errs, checkFiles(cmd.WorkingDir, contents, orphans, logger))...)
+	}
+
+	if !cmd.NoValidate && len(contents) > 0 {
+		logger.Info(util.ColorGreen(appName) + " is type-checking generated code")
+		if err := validateFiles(cmd.WorkingDir, contents, orphans, logger); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+	}
+
+	if len(contents) == 0 {
 		logger.Info(util.ColorGreen(appName) + " generated nothing")
 	} else {
 		if cmd.DryRun {
 			logger.Info(util.ColorGreen(appName) + " is printing generated file content")
-			for p, out := range outs {
+			for _, p := range slices.Sorted(maps.Keys(contents)) {
 				rp := filepath.Join(cmd.WorkingDir, p)
 				logger.Info(util.ColorGreen(appName) + " will save to file " + util.ColorBlue(rp))
-				util.PrintFileWithFunction(p, []byte(out.GoString()), func(l string) {
+				util.PrintFileWithFunction(p, contents[p], func(l string) {
 					logger.Info(l)
 				})
 			}
 		} else {
 			logger.Info(util.ColorGreen(appName) + " is saving generated file to disk")
-			for p, out := range outs {
+			for _, p := range slices.Sorted(maps.Keys(contents)) {
+				rp := filepath.Join(cmd.WorkingDir, p)
+				written, err := writeFile(rp, contents[p])
+				if err != nil {
+					logger.Error(fmt.Sprintf("cannot save to file %s: %s", util.ColorBlue(rp), util.ColorRed(err.Error())))
+					errs = append(errs, fmt.Errorf("write %s: %w", rp, err))
+					continue
+				}
+
+				if written {
+					logger.Info(util.ColorGreen(appName) + " saved to file " + util.ColorBlue(rp))
+				} else {
+					logger.Info(util.ColorGreen(appName) + " skipped unchanged file " + util.ColorBlue(rp))
+				}
+			}
+		}
+	}
+
+	if len(orphans) > 0 {
+		if cmd.DryRun {
+			for _, p := range orphans {
+				logger.Info(util.ColorGreen(appName) + " will delete orphaned file " + util.ColorRed(filepath.Join(cmd.WorkingDir, p)))
+			}
+		} else {
+			logger.Info(util.ColorGreen(appName) + " is deleting orphaned generated files")
+			for _, p := range orphans {
 				rp := filepath.Join(cmd.WorkingDir, p)
-				_ = os.WriteFile(rp, []byte(out.GoString()), 0644)
-				logger.Info(util.ColorGreen(appName) + " saved to file " + util.ColorBlue(rp))
+				if err := os.Remove(rp); err != nil {
+					logger.Error(fmt.Sprintf("cannot delete file %s: %s", util.ColorBlue(rp), util.ColorRed(err.Error())))
+					errs = append(errs, fmt.Errorf("delete %s: %w", rp, err))
+					continue
+				}
+				logger.Info(util.ColorGreen(appName) + " deleted file " + util.ColorRed(rp))
 			}
 		}
 	}
 
 	logger.Info("")
-	return nil
+	return errors.Join(errs...)
+}
+
+// generatePackages generates the packages with up to jobs workers. Without keepGoing no package
+// is started after a package fails. The failures are logged and returned in the order of pkgs.
+func generatePackages(generator gen.Generator, sourceDir string, pkgs []*packages.Package, configs map[string][]gen.PackageConfig, jobs int, keepGoing bool, logger *slog.Logger) GenerateErrors {
+	groups := groupPackagesByOutputDir(sourceDir, pkgs, configs)
+	jobs = max(1, min(jobs, len(groups)))
+	results := make([]*gen.GenerateError, len(pkgs))
+
+	var failed atomic.Bool
+	var wg sync.WaitGroup
+	queue := make(chan []int)
+	for range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for group := range queue {
+				for _, i := range group {
+					if failed.Load() && !keepGoing {
+						continue
+					}
+
+					pkg := pkgs[i]
+					logger.Info(util.ColorGreen(appName) + " is generating for package " + util.ColorCyan(pkg.PkgPath))
+					if ge := generate(generator, pkg, configs[pkg.PkgPath]); ge != nil {
+						results[i] = ge
+						failed.Store(true)
+					}
+				}
+			}
+		}()
+	}
+
+	for _, group := range groups {
+		queue <- group
+	}
+	close(queue)
+	wg.Wait()
+
+	var generateErrs GenerateErrors
+	for _, ge := range results {
+		if ge == nil {
+			continue
+		}
+		logger.Error(fmt.Sprintf("cannot generate for package %s: %s", util.ColorCyan(ge.PkgPath), util.ColorRed(ge.Error())))
+		generateErrs = append(generateErrs, ge)
+	}
+	return generateErrs
+}
+
+// groupPackagesByOutputDir returns the indexes of pkgs grouped by the directories which they
+// generate files into, in the order of pkgs. A group is generated by one worker in order, so a
+// file shared by several packages is not written concurrently and its content does not depend
+// on the number of jobs.
+func groupPackagesByOutputDir(sourceDir string, pkgs []*packages.Package, configs map[string][]gen.PackageConfig) [][]int {
+	// parent links a package to a package generated before it in the same group
+	parent := make([]int, len(pkgs))
+	for i := range parent {
+		parent[i] = i
+	}
+	root := func(i int) int {
+		for parent[i] != i {
+			i = parent[i]
+		}
+		return i
+	}
+
+	owners := make(map[string]int)
+	for i, pkg := range pkgs {
+		for _, cf := range configs[pkg.PkgPath] {
+			dir := pkg.Dir
+			if cf.Output.Dir != "" {
+				dir = filepath.Join(sourceDir, cf.Output.Dir)
+			}
+
+			owner, ok := owners[dir]
+			if !ok {
+				owners[dir] = i
+				continue
+			}
+
+			a, b := root(owner), root(i)
+			parent[max(a, b)] = min(a, b)
+		}
+	}
+
+	var groups [][]int
+	indexes := make(map[int]int)
+	for i := range pkgs {
+		r := root(i)
+		g, ok := indexes[r]
+		if !ok {
+			g = len(groups)
+			indexes[r] = g
+			groups = append(groups, nil)
+		}
+		groups[g] = append(groups[g], i)
+	}
+	return groups
+}
+
+// generate generates a package, a panic is recovered so the other packages can be generated.
+func generate(generator gen.Generator, pkg *packages.Package, configs []gen.PackageConfig) (ge *gen.GenerateError) {
+	defer func() {
+		if r := recover(); r != nil {
+			ge = &gen.GenerateError{PkgPath: pkg.PkgPath, Err: fmt.Errorf("panic: %v", r)}
+		}
+	}()
+
+	if err := generator.Generate(pkg, configs); err != nil {
+		if !errors.As(err, &ge) {
+			ge = &gen.GenerateError{PkgPath: pkg.PkgPath, Err: err}
+		}
+	}
+	return ge
 }
 
 func loadLibraryConverters(cf gen.LibraryConverterConfig) {
@@ -141,4 +360,7 @@ func loadLibraryConverters(cf gen.LibraryConverterConfig) {
 	if cf.UseSQL {
 		sql.RegisterConverters()
 	}
+	if cf.UseUUID {
+		uuid.RegisterConverters()
+	}
 }
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gen "github.com/toniphan21/go-mapper-gen"
+	"github.com/toniphan21/go-mapper-gen/internal/setup/file"
+	"golang.org/x/tools/go/packages"
+)
+
+const testModule = "github.com/toniphan21/go-mapper-gen/test"
+
+func TestGeneratePackages_jobsWithSharedOutputDir(t *testing.T) {
+	files := []file.File{file.New("go.mod", gen.Test.MakeGoModFileContent(testModule, nil, nil))}
+	configs := make(map[string][]gen.PackageConfig)
+	for i := range 8 {
+		name := fmt.Sprintf("p%d", i)
+		files = append(files, file.New(name+"/code.go", gen.Test.FileLines(
+			`package `+name,
+			``,
+			`type Target struct {`,
+			`	ID   int64`,
+			`	Name *string`,
+			`}`,
+			``,
+			`type Source struct {`,
+			`	ID   int`,
+			`	Name string`,
+			`}`,
+		)))
+		configs[testModule+"/"+name] = []gen.PackageConfig{sharedOutputDirConfig(name)}
+	}
+
+	parser, err := gen.Test.Parse(t, files)
+	require.NoError(t, err)
+
+	var pkgs []*packages.Package
+	for _, pkg := range parser.SourcePackages() {
+		if _, ok := configs[pkg.PkgPath]; ok {
+			pkgs = append(pkgs, pkg)
+		}
+	}
+	require.Len(t, pkgs, 8)
+
+	groups := groupPackagesByOutputDir(parser.SourceDir(), pkgs, configs)
+	assert.Equal(t, [][]int{{0, 1, 2, 3, 4, 5, 6, 7}}, groups)
+
+	run := func(jobs int) map[string]string {
+		registry := gen.NewRegistry()
+		registry.RegisterAllBuiltin()
+
+		fm := gen.DefaultFileManager()
+		generator := gen.New(parser, gen.Config{}, gen.WithFileManager(fm), gen.WithRegistry(registry))
+		errs := generatePackages(generator, parser.SourceDir(), pkgs, configs, jobs, false, gen.NewNoopLogger())
+		require.Empty(t, errs)
+
+		contents, err := renderFiles(fm.JenFiles())
+		require.NoError(t, err)
+
+		result := make(map[string]string)
+		for k, v := range contents {
+			result[k] = string(v)
+		}
+		return result
+	}
+
+	expected := run(1)
+	require.Len(t, expected, 1)
+	for range 5 {
+		assert.Equal(t, expected, run(8))
+	}
+}
+
+func sharedOutputDirConfig(name string) gen.PackageConfig {
+	pkgPath := testModule + "/" + name
+	return gen.PackageConfig{
+		Mode: gen.ModeTypes,
+		Output: gen.Output{
+			PkgName:      "mapping",
+			FileName:     "gen_mapper.go",
+			TestFileName: "gen_mapper_test.go",
+			Dir:          "mapping",
+			ImportPath:   testModule + "/mapping",
+		},
+		InterfaceName:      name + "Mapper",
+		ImplementationName: name + "MapperImpl",
+		ConstructorName:    "new_" + name + "Mapper",
+		DecoratorMode:      gen.DecoratorModeNever,
+		Structs: []gen.StructConfig{
+			{
+				MapperName:               "Target",
+				TargetPkgPath:            pkgPath,
+				TargetStructName:         "Target",
+				SourcePkgPath:            pkgPath,
+				SourceStructName:         "Source",
+				SourceToTargetFuncName:   "ToTarget",
+				SourceFromTargetFuncName: "FromTarget",
+				DecorateFuncName:         "decorateTarget",
+				GenerateSourceToTarget:   true,
+				GenerateSourceFromTarget: true,
+			},
+		},
+	}
+}
@@ -0,0 +1,229 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"log/slog"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/toniphan21/go-mapper-gen/internal/util"
+	"golang.org/x/tools/go/packages"
+)
+
+// ErrInvalidGeneratedCode is returned by Run if a generated file cannot be rendered or does not
+// compile together with its package.
+var ErrInvalidGeneratedCode = errors.New("generated code does not compile")
+
+// renderFiles renders the generated files, the content is formatted by gofmt and the imports are
+// managed by jennifer.
+func renderFiles(outs map[string]*jen.File) (map[string][]byte, error) {
+	contents := make(map[string][]byte, len(outs))
+	var errs []error
+	for _, p := range slices.Sorted(maps.Keys(outs)) {
+		buf := &bytes.Buffer{}
+		if err := outs[p].Render(buf); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", p, err))
+			continue
+		}
+		contents[p] = buf.Bytes()
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidGeneratedCode, errors.Join(errs...))
+	}
+	return contents, nil
+}
+
+// validateFiles type-checks the generated files together with their packages as if they were
+// written to disk, orphaned files are treated as removed. A compile error in a generated file is
+// reported with the function and the field which produced it.
+func validateFiles(workingDir string, contents map[string][]byte, orphans []string, logger *slog.Logger) error {
+	// the overlay is keyed by absolute paths
+	workingDir, err := filepath.Abs(workingDir)
+	if err != nil {
+		return err
+	}
+
+	overlay := make(map[string][]byte)
+	dirs := make(map[string]bool)
+	for p, content := range contents {
+		overlay[filepath.Join(workingDir, p)] = content
+		dirs["./"+filepath.ToSlash(filepath.Dir(p))] = true
+	}
+
+	for _, p := range orphans {
+		rp := filepath.Join(workingDir, p)
+		f, err := parser.ParseFile(token.NewFileSet(), rp, nil, parser.PackageClauseOnly)
+		if err != nil {
+			return err
+		}
+		overlay[rp] = []byte("package " + f.Name.Name + "\n")
+	}
+
+	cfg := &packages.Config{
+		Mode:    packages.NeedName | packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo,
+		Dir:     workingDir,
+		Tests:   true,
+		Overlay: overlay,
+	}
+	pkgs, err := packages.Load(cfg, slices.Sorted(maps.Keys(dirs))...)
+	if err != nil {
+		return err
+	}
+
+	var messages []string
+	seen := make(map[string]bool)
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		for _, e := range pkg.Errors {
+			if e.Kind == packages.ListError {
+				continue
+			}
+
+			rp, line, col, ok := splitErrorPos(e.Pos)
+			if !ok {
+				continue
+			}
+			content, generated := overlay[rp]
+			if !generated || seen[e.Pos+e.Msg] {
+				continue
+			}
+			seen[e.Pos+e.Msg] = true
+
+			p, _ := filepath.Rel(workingDir, rp)
+			message := fmt.Sprintf("%s:%d:%d: ", p, line, col)
+			if fn, field := findErrorOrigin(content, line); fn != "" {
+				message += fn + ": "
+				if field != "" {
+					message += "field " + field + ": "
+				}
+			}
+			messages = append(messages, message+e.Msg)
+		}
+	})
+
+	if len(messages) == 0 {
+		return nil
+	}
+
+	slices.Sort(messages)
+	for _, message := range messages {
+		logger.Error(util.ColorRed(message))
+	}
+	return fmt.Errorf("%w: %d error(s)", ErrInvalidGeneratedCode, len(messages))
+}
+
+// splitErrorPos splits the position of a packages.Error, which is file:line:col.
+func splitErrorPos(pos string) (string, int, int, bool) {
+	rest, colText, ok := cutLast(pos, ":")
+	if !ok {
+		return "", 0, 0, false
+	}
+	file, lineText, ok := cutLast(rest, ":")
+	if !ok {
+		return "", 0, 0, false
+	}
+
+	line, err := strconv.Atoi(lineText)
+	if err != nil {
+		return "", 0, 0, false
+	}
+	col, err := strconv.Atoi(colText)
+	if err != nil {
+		return "", 0, 0, false
+	}
+	return file, line, col, true
+}
+
+func cutLast(s, sep string) (string, string, bool) {
+	i := strings.LastIndex(s, sep)
+	if i < 0 {
+		return "", "", false
+	}
+	return s[:i], s[i+len(sep):], true
+}
+
+// findErrorOrigin returns the function which contains the line of a generated file, and the
+// target field if the line is in the code which converts a field. The converted value may be
+// assigned later, e.g. after checking an error, so the first statement at or after the line
+// which assigns out.Field is used.
+func findErrorOrigin(content []byte, line int) (string, string) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", content, 0)
+	if err != nil {
+		return "", ""
+	}
+
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		if line < fset.Position(fn.Pos()).Line || line > fset.Position(fn.End()).Line {
+			continue
+		}
+
+		name := fn.Name.Name
+		if fn.Recv != nil && len(fn.Recv.List) == 1 {
+			if recv := receiverName(fn.Recv.List[0].Type); recv != "" {
+				name = recv + "." + name
+			}
+		}
+
+		for _, stmt := range fn.Body.List {
+			if fset.Position(stmt.End()).Line < line {
+				continue
+			}
+			if field := assignedOutField(stmt); field != "" {
+				return name, field
+			}
+		}
+		return name, ""
+	}
+	return "", ""
+}
+
+func receiverName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// assignedOutField returns the field of out which is assigned in the statement.
+func assignedOutField(stmt ast.Stmt) string {
+	var field string
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		if field != "" {
+			return false
+		}
+
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok {
+			return true
+		}
+		for _, lhs := range assign.Lhs {
+			sel, ok := lhs.(*ast.SelectorExpr)
+			if !ok {
+				continue
+			}
+			if ident, ok := sel.X.(*ast.Ident); ok && ident.Name == "out" {
+				field = sel.Sel.Name
+				return false
+			}
+		}
+		return true
+	})
+	return field
+}
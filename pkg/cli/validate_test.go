@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gen "github.com/toniphan21/go-mapper-gen"
+	"github.com/toniphan21/go-mapper-gen/internal/util"
+)
+
+func Test_validateFiles(t *testing.T) {
+	dir := t.TempDir()
+	sources := map[string][]byte{
+		"go.mod": gen.Test.MakeGoModFileContent(testModule, nil, nil),
+		"a/code.go": gen.Test.FileLines(
+			`package a`,
+			``,
+			`type Target struct {`,
+			`	ID int`,
+			`}`,
+			``,
+			`type Source struct {`,
+			`	ID string`,
+			`}`,
+		),
+		// an orphaned file which declares the same function as the generated file
+		"a/old_mapper.go": gen.Test.FileLines(
+			`package a`,
+			``,
+			`func ToTarget(in Source) Target { return Target{} }`,
+		),
+	}
+	for p, content := range sources {
+		path := filepath.Join(dir, p)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, content, 0644))
+	}
+
+	mapper := func(assignment string) map[string][]byte {
+		return map[string][]byte{"a/gen_mapper.go": gen.Test.FileLines(
+			`package a`,
+			``,
+			`func ToTarget(in Source) Target {`,
+			`	var out Target`,
+			``,
+			`	`+assignment,
+			``,
+			`	return out`,
+			`}`,
+		)}
+	}
+	orphans := []string{"a/old_mapper.go"}
+
+	t.Run("valid code", func(t *testing.T) {
+		assert.NoError(t, validateFiles(dir, mapper("out.ID = len(in.ID)"), orphans, gen.NewNoopLogger()))
+	})
+
+	t.Run("a file which is not orphaned is type-checked", func(t *testing.T) {
+		err := validateFiles(dir, mapper("out.ID = len(in.ID)"), nil, gen.NewNoopLogger())
+
+		assert.ErrorIs(t, err, ErrInvalidGeneratedCode)
+	})
+
+	t.Run("the error is reported with the function and the field", func(t *testing.T) {
+		util.DisableColor()
+		buf := &bytes.Buffer{}
+		logger := slog.New(slog.NewTextHandler(buf, nil))
+
+		err := validateFiles(dir, mapper("out.ID = in.ID"), orphans, logger)
+
+		assert.ErrorIs(t, err, ErrInvalidGeneratedCode)
+		assert.Contains(t, buf.String(), filepath.Join("a", "gen_mapper.go")+":6:11: ToTarget: field ID: cannot use in.ID")
+	})
+}
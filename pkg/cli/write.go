@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const defaultFileMode fs.FileMode = 0644
+
+// writeFile writes content to path via a temp file in the same directory and a rename, so a
+// reader never sees a partially written file. The file is not touched if its content is
+// unchanged, an existing file keeps its mode. It returns whether the file was written.
+func writeFile(path string, content []byte) (bool, error) {
+	mode := defaultFileMode
+	stat, err := os.Stat(path)
+	switch {
+	case err == nil:
+		mode = stat.Mode().Perm()
+		current, err := os.ReadFile(path)
+		if err != nil {
+			return false, err
+		}
+		if bytes.Equal(current, content) {
+			return false, nil
+		}
+
+	case os.IsNotExist(err):
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return false, err
+		}
+
+	default:
+		return false, err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		return false, err
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		_ = tmp.Close()
+		return false, err
+	}
+	if err := tmp.Close(); err != nil {
+		return false, err
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_writeFile(t *testing.T) {
+	t.Run("creates the file and its directory", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "mapping", "gen_mapper.go")
+
+		written, err := writeFile(path, []byte("package mapping\n"))
+
+		require.NoError(t, err)
+		assert.True(t, written)
+		assertFile(t, path, "package mapping\n", defaultFileMode)
+	})
+
+	t.Run("skips unchanged content", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "gen_mapper.go")
+		require.NoError(t, os.WriteFile(path, []byte("package a\n"), 0644))
+		old := time.Now().Add(-time.Hour)
+		require.NoError(t, os.Chtimes(path, old, old))
+
+		written, err := writeFile(path, []byte("package a\n"))
+
+		require.NoError(t, err)
+		assert.False(t, written)
+		stat, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.True(t, stat.ModTime().Equal(old), "the file must not be touched")
+	})
+
+	t.Run("replaces changed content and keeps the mode", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "gen_mapper.go")
+		require.NoError(t, os.WriteFile(path, []byte("package a\n"), 0600))
+
+		written, err := writeFile(path, []byte("package b\n"))
+
+		require.NoError(t, err)
+		assert.True(t, written)
+		assertFile(t, path, "package b\n", 0600)
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1, "the temp file must be removed")
+	})
+}
+
+func assertFile(t *testing.T, path string, content string, mode os.FileMode) {
+	t.Helper()
+
+	actual, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(actual))
+
+	stat, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, mode, stat.Mode().Perm())
+}
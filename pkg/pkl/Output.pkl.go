@@ -7,4 +7,28 @@ type Output struct {
 	FileName *string `pkl:"file_name"`
 
 	TestFileName *string `pkl:"test_file_name"`
+
+	// The directory of the generated files relative to the working directory,
+	// e.g. "internal/mapping". The files are generated into the package of the
+	// configured package if it is not set.
+	//
+	// The generated code lives in another package, so the structs are
+	// qualified and unexported fields are not mapped. {CurrentPackageName} in
+	// package is the last element of import_path.
+	Dir *string `pkl:"dir"`
+
+	// The import path of the package in dir, required if dir is set.
+	ImportPath *string `pkl:"import_path"`
+
+	// Controls how the generated code is split into files.
+	//
+	// - "none": Generates all mappers into file_name.
+	// - "per-struct": Generates the mapping code of each struct into
+	//   struct_file_name, the shared interface, constructor and decorator types
+	//   are generated into file_name.
+	Split *string `pkl:"split"`
+
+	// Name of the file of a struct when split = "per-struct", supports
+	// {TargetStructName} and {SourceStructName} placeholders.
+	StructFileName *string `pkl:"struct_file_name"`
 }
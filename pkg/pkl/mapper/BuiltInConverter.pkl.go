@@ -14,5 +14,21 @@ type BuiltInConverter struct {
 
 	EnableFunctions bool `pkl:"enable_functions"`
 
+	// Enables the converter between time.Time and string/int64, configured via converter { time }.
+	EnableTime bool `pkl:"enable_time"`
+
+	// Enables the converter between string and numeric/bool types using strconv, configured via converter { strconv }.
+	EnableStrconv bool `pkl:"enable_strconv"`
+
+	// Enables the converter between a type and string/[]byte using its String(), MarshalText()/UnmarshalText()
+	// or MarshalBinary()/UnmarshalBinary() methods. Methods returning an error are only used when the mapper
+	// returns an error.
+	EnableText bool `pkl:"enable_text"`
+
+	// Enables auto-discovery of conversion methods and constructors: an exported zero-arg method on the source
+	// type returning the target type, e.g. func (e Email) ToString() string, or a function named <Type>From<Source>
+	// or New<Type> in the target type's package which takes the source type. The chosen method is logged in verbose mode.
+	EnableDiscovery bool `pkl:"enable_discovery"`
+
 	Library BuiltInLibraryConverter `pkl:"library"`
 }
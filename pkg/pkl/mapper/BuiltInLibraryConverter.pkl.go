@@ -7,4 +7,24 @@ type BuiltInLibraryConverter struct {
 	EnablePgtype bool `pkl:"enable_pgtype"`
 
 	EnableSql bool `pkl:"enable_sql"`
+
+	// Enables converters for github.com/google/uuid and github.com/gofrs/uuid types.
+	EnableUuid bool `pkl:"enable_uuid"`
+
+	// Controls how a string is parsed into uuid.UUID.
+	//
+	// - "error": the parse error is returned, only available when the mapper returns an error.
+	// - "must": the generated code panics if the string is not a valid UUID.
+	UuidParse string `pkl:"uuid_parse"`
+
+	// Controls how nullable library types (pgtype.*, sql.Null*) are converted
+	// from/to their non-pointer value types. Can be overridden per field.
+	//
+	// - "pointer": the conversion goes through the pointer type, e.g. pgtype.Text -> *string -> string.
+	// - "zero-on-null": NULL becomes the zero value, a value is always valid.
+	// - "valid-if-non-zero": NULL becomes the zero value, the zero value becomes NULL.
+	// - "always-valid": the value is read without checking validity, a value is always valid.
+	//
+	// When not set, the mode is "pointer".
+	NullMode *string `pkl:"null_mode"`
 }
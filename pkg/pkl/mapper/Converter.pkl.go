@@ -4,6 +4,16 @@ package mapper
 type Converter struct {
 	BuiltIn BuiltInConverter `pkl:"built_in"`
 
+	Numeric NumericConverter `pkl:"numeric"`
+
+	Time TimeConverter `pkl:"time"`
+
+	Strconv StrconvConverter `pkl:"strconv"`
+
+	// List of converter functions in the form "pkg/path.FuncName" or "pkg/path.VariableName" to use methods of a
+	// variable. The name can be a wildcard pattern, e.g. "pkg/path.*" or "pkg/path.To*", which registers every exported
+	// function with one parameter and one result, and the methods of every exported variable, matched in the package.
+	// Functions in the source code which have a //mapper:converter doc comment are registered as well.
 	Functions *[]string `pkl:"functions"`
 
 	Priorities []string `pkl:"priorities"`
@@ -34,7 +34,13 @@ type Package interface {
 
 	GetGenerateSourceFromTarget() bool
 
+	GetReturnError() bool
+
 	GetGenerateGoDoc() bool
+
+	GetGenerateTest() bool
+
+	GetGenerateRoundTripReport() bool
 }
 
 var _ Package = PackageImpl{}
@@ -136,8 +142,32 @@ type PackageImpl struct {
 	// Can be overridden per struct.
 	GenerateSourceFromTarget bool `pkl:"generate_source_from_target"`
 
+	// Whether the generated mapping functions return an error as the
+	// last result. Converters which can fail, such as parsing a string,
+	// return the error instead of panicking or being unconvertible.
+	//
+	// Can be overridden per struct.
+	ReturnError bool `pkl:"return_error"`
+
 	// Whether to generate GoDoc comments for generated code.
 	GenerateGoDoc bool `pkl:"generate_go_doc"`
+
+	// Whether to generate round-trip tests into output.test_file_name for
+	// the structs which generate both source-to-target and
+	// target-to-source mapping code.
+	//
+	// A test populates a source value, maps it to the target and back,
+	// then asserts the fields which are mapped both ways are equal.
+	GenerateTest bool `pkl:"generate_test"`
+
+	// Whether to add a round-trip report to the GoDoc comment of the
+	// source-to-target mapping code, which lists the fields that are not
+	// equal after mapping to the target and back, e.g. fields mapped one
+	// way only or narrowed numeric conversions.
+	//
+	// The report is always logged when both source-to-target and
+	// target-to-source mapping code are generated.
+	GenerateRoundTripReport bool `pkl:"generate_round_trip_report"`
 }
 
 // Controls the overall generation strategy.
@@ -264,7 +294,37 @@ func (rcv PackageImpl) GetGenerateSourceFromTarget() bool {
 	return rcv.GenerateSourceFromTarget
 }
 
+// Whether the generated mapping functions return an error as the
+// last result. Converters which can fail, such as parsing a string,
+// return the error instead of panicking or being unconvertible.
+//
+// Can be overridden per struct.
+func (rcv PackageImpl) GetReturnError() bool {
+	return rcv.ReturnError
+}
+
 // Whether to generate GoDoc comments for generated code.
 func (rcv PackageImpl) GetGenerateGoDoc() bool {
 	return rcv.GenerateGoDoc
 }
+
+// Whether to generate round-trip tests into output.test_file_name for
+// the structs which generate both source-to-target and
+// target-to-source mapping code.
+//
+// A test populates a source value, maps it to the target and back,
+// then asserts the fields which are mapped both ways are equal.
+func (rcv PackageImpl) GetGenerateTest() bool {
+	return rcv.GenerateTest
+}
+
+// Whether to add a round-trip report to the GoDoc comment of the
+// source-to-target mapping code, which lists the fields that are not
+// equal after mapping to the target and back, e.g. fields mapped one
+// way only or narrowed numeric conversions.
+//
+// The report is always logged when both source-to-target and
+// target-to-source mapping code are generated.
+func (rcv PackageImpl) GetGenerateRoundTripReport() bool {
+	return rcv.GenerateRoundTripReport
+}
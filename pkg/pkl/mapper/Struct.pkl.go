@@ -28,6 +28,29 @@ type Struct struct {
 	// If not set, the source struct name is inferred from context.
 	SourceStructName *string `pkl:"source_struct_name"`
 
+	// Source structs of a multi-source mapping, keyed by the parameter name of the generated function.
+	//
+	// A value is a struct name in source_pkg, or a struct name qualified by a package path or a
+	// package name, e.g. sources { ["user"] = "db.User"; ["profile"] = "db.Profile" } generates
+	// ToUserView(user db.User, profile db.Profile) UserView. When set, source_struct_name is ignored
+	// and only the source-to-target function is generated.
+	//
+	// Fields in fields { map } can be qualified by the parameter name, e.g. ["Bio"] = "profile.Bio".
+	Sources *map[string]string `pkl:"sources"`
+
+	// Precedence of the sources when a field is matched in more than one source, the first source wins.
+	//
+	// Sources which are not listed follow the listed ones, ordered by name.
+	SourcePrecedence *[]string `pkl:"source_precedence"`
+
+	// Extra parameters of the generated mapping functions, keyed by the parameter name, the value is
+	// the type, e.g. params { ["tenantID"] = "string"; ["clock"] = "github.com/acme/app/clock.Clock" }
+	// generates ToTarget(in Source, clock clock.Clock, tenantID string). The parameters are ordered by name.
+	//
+	// A parameter can be mapped to a target field via fields { map }, e.g. ["TenantID"] = "$tenantID", or
+	// passed to a function via set.via_function_with_param().
+	Params *map[string]string `pkl:"params"`
+
 	// Template for the source-to-target mapping function name.
 	//
 	// Overrides package level source_to_target_function_name when set.
@@ -84,4 +107,10 @@ type Struct struct {
 	//
 	// Overrides package level generate_source_from_target when set.
 	GenerateSourceFromTarget *bool `pkl:"generate_source_from_target"`
+
+	// Whether the generated mapping functions return an error as the
+	// last result.
+	//
+	// Overrides package level return_error when set.
+	ReturnError *bool `pkl:"return_error"`
 }
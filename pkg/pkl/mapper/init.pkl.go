@@ -7,6 +7,9 @@ func init() {
 	pkl.RegisterStrictMapping("gomappergen.mapper#Converter", Converter{})
 	pkl.RegisterStrictMapping("gomappergen.mapper#BuiltInConverter", BuiltInConverter{})
 	pkl.RegisterStrictMapping("gomappergen.mapper#BuiltInLibraryConverter", BuiltInLibraryConverter{})
+	pkl.RegisterStrictMapping("gomappergen.mapper#NumericConverter", NumericConverter{})
+	pkl.RegisterStrictMapping("gomappergen.mapper#TimeConverter", TimeConverter{})
+	pkl.RegisterStrictMapping("gomappergen.mapper#StrconvConverter", StrconvConverter{})
 	pkl.RegisterStrictMapping("gomappergen.mapper", Mapper{})
 	pkl.RegisterStrictMapping("gomappergen.mapper#FieldInterceptor", FieldInterceptor{})
 	pkl.RegisterStrictMapping("gomappergen.mapper#Fields", Fields{})
@@ -31,6 +31,7 @@ type ConverterTestCase struct {
 	SourceSymbolWithoutFieldName bool
 	TargetSymbolMetadata         SymbolMetadata
 	SourceSymbolMetadata         SymbolMetadata
+	ReturnError                  bool
 	ExpectedCanConvert           bool
 	ExpectedImports              []string
 	ExpectedCode                 []string
@@ -116,7 +117,7 @@ func (h *converterTest) RunConverterTestCase(t *testing.T, tc ConverterTestCase,
 	jf := jen.NewFilePathName(goMod.GetModule(), "test")
 	ctx := &converterContext{
 		Context:           context.Background(),
-		lookupContext:     newLookupContext(targetDescriptor, sourceDescriptor, NewNoopLogger()),
+		lookupContext:     newLookupContext(targetDescriptor, sourceDescriptor, tc.ReturnError, NewNoopLogger()),
 		jenFile:           jf,
 		parser:            parser,
 		emitTraceComments: tc.EmitTraceComments,
@@ -170,10 +171,16 @@ func (h *converterTest) RunConverterTestCase(t *testing.T, tc ConverterTestCase,
 		blocks = append(blocks, jen.Comment("empty"))
 	}
 
+	var results []jen.Code
+	if tc.ReturnError {
+		results = append(results, jen.Error())
+		blocks = append(blocks, jen.Return(jen.Nil()))
+	}
+
 	jf.Func().Id("convert").Params(
 		jen.Id("in").Add(jen.Op("*").Add(GeneratorUtil.TypeToJenCode(sourceStruct.Type))),
 		jen.Id("out").Add(jen.Op("*").Add(GeneratorUtil.TypeToJenCode(targetStruct.Type))),
-	).Block(blocks...).Line()
+	).Params(results...).Block(blocks...).Line()
 
 	expected := []string{
 		`package test`,
@@ -186,7 +193,11 @@ func (h *converterTest) RunConverterTestCase(t *testing.T, tc ConverterTestCase,
 		expected = append(expected, "")
 	}
 
-	expected = append(expected, `func convert(in *Source, out *Target) {`)
+	if tc.ReturnError {
+		expected = append(expected, `func convert(in *Source, out *Target) error {`)
+	} else {
+		expected = append(expected, `func convert(in *Source, out *Target) {`)
+	}
 	if tc.TargetSymbolWithoutFieldName {
 		expected = append(expected, "\tvar target "+tc.TargetType)
 	}
@@ -203,6 +214,9 @@ func (h *converterTest) RunConverterTestCase(t *testing.T, tc ConverterTestCase,
 	if tc.TargetSymbolWithoutFieldName {
 		expected = append(expected, "\tout.targetField = target")
 	}
+	if tc.ReturnError {
+		expected = append(expected, "\treturn nil")
+	}
 	expected = append(expected, "}")
 	expected = append(expected, "")
 
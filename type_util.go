@@ -88,7 +88,21 @@ func (u *typeUtil) IsIdentical(t1 types.Type, t2 types.Type) bool {
 		path2 = obj2.Pkg().Path()
 	}
 
-	return path1 == path2 && obj1.Name() == obj2.Name()
+	if path1 != path2 || obj1.Name() != obj2.Name() {
+		return false
+	}
+
+	// instantiated generic types such as sql.Null[T] must have identical type arguments
+	args1, args2 := n1.TypeArgs(), n2.TypeArgs()
+	if args1.Len() != args2.Len() {
+		return false
+	}
+	for i := 0; i < args1.Len(); i++ {
+		if !u.IsIdentical(args1.At(i), args2.At(i)) {
+			return false
+		}
+	}
+	return true
 }
 
 func (u *typeUtil) MakeNamedType(pkgPath, pkgName, typeName string) types.Type {